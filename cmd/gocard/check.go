@@ -0,0 +1,44 @@
+// File: cmd/gocard/check.go
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/DavidMiserak/GoCard/internal/data"
+)
+
+// runCheck loads the collection in deckDir and lists every file that could
+// not be loaded, one problem per line. It returns the exit status: 0 when
+// the collection loads cleanly, 1 when problems were found.
+func runCheck(deckDir string, out io.Writer) int {
+	if _, err := os.Stat(deckDir); err != nil {
+		fmt.Fprintf(out, "Error: %v\n", err)
+		return 1
+	}
+
+	store, err := data.NewStoreFromDir(deckDir)
+	if err != nil {
+		fmt.Fprintf(out, "Error loading decks: %v\n", err)
+		return 1
+	}
+
+	cards := 0
+	for _, deck := range store.GetDecks() {
+		cards += len(deck.Cards)
+	}
+
+	for _, problem := range store.Report.Errors {
+		fmt.Fprintln(out, problem)
+	}
+
+	problems := len(store.Report.Errors)
+	fmt.Fprintf(out, "Decks: %d, cards: %d, problems: %d\n", len(store.GetDecks()), cards, problems)
+
+	if problems > 0 {
+		return 1
+	}
+	return 0
+}
@@ -8,7 +8,9 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/DavidMiserak/GoCard/internal/clock"
 	"github.com/DavidMiserak/GoCard/internal/data"
+	"github.com/DavidMiserak/GoCard/internal/srs"
 	"github.com/DavidMiserak/GoCard/internal/ui"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -16,34 +18,110 @@ import (
 func main() {
 	// Parse command-line flags
 	var deckDir string
+	var schedulerName string
+	var nowValue string
 	defaultDir := filepath.Join(os.Getenv("HOME"), "GoCard")
 	flag.StringVar(&deckDir, "dir", defaultDir, "Directory containing flashcard decks")
+	flag.StringVar(&schedulerName, "scheduler", "",
+		fmt.Sprintf("Scheduling algorithm %v, overrides the collection config", srs.SchedulerNames()))
+	flag.StringVar(&nowValue, "now", os.Getenv(clock.EnvVar),
+		fmt.Sprintf("Run as if the current time were this date (YYYY-MM-DD[THH:MM]), also set by %s", clock.EnvVar))
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: gocard [options] [check]\n\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "Commands:\n  check\tList the files of the collection that cannot be loaded\n\nOptions:\n")
+		flag.PrintDefaults()
+	}
 	flag.Parse()
 
+	// The check command takes its own options after its name
+	command := flag.Arg(0)
+	if command == "check" {
+		checkFlags := flag.NewFlagSet("check", flag.ExitOnError)
+		checkFlags.StringVar(&deckDir, "dir", deckDir, "Directory containing flashcard decks")
+		checkFlags.Usage = func() {
+			fmt.Fprintf(checkFlags.Output(), "Usage: gocard [options] check [-dir path]\n\nOptions:\n")
+			checkFlags.PrintDefaults()
+		}
+		checkFlags.Parse(flag.Args()[1:]) //nolint:errcheck // Exits on error
+		if checkFlags.NArg() > 0 {
+			fmt.Printf("Error: unexpected arguments to check: %v\n", checkFlags.Args())
+			checkFlags.Usage()
+			os.Exit(2)
+		}
+	}
+
+	// Pretend the session starts at another time if requested
+	var clk clock.Clock = clock.Real{}
+	if nowValue != "" {
+		start, err := clock.Parse(nowValue)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		clk = clock.StartingAt(start)
+	}
+
 	// Resolve tilde in path if present
 	if deckDir == "~/GoCard" || deckDir == "~/GoCard/" {
 		deckDir = defaultDir
 	}
 
+	// Check the collection instead of starting the TUI if requested
+	if command == "check" {
+		os.Exit(runCheck(deckDir, os.Stdout))
+	} else if command != "" {
+		fmt.Printf("Error: unknown command %q\n", command)
+		flag.Usage()
+		os.Exit(2)
+	}
+
 	// Initialize the store
 	var store *data.Store
+	var watchDir string // Directory of the loaded collection, to reload on changes
 
 	// Check if directory exists and load decks from it
 	if _, err := os.Stat(deckDir); os.IsNotExist(err) {
 		fmt.Printf("Warning: Directory '%s' does not exist. Using default decks.\n", deckDir)
-		store = data.NewStore() // Use default store with dummy data
+		store = data.NewStoreWithClock(clk) // Use default store with dummy data
 	} else {
 		// Load decks from the specified directory
 		var err error
-		store, err = data.NewStoreFromDir(deckDir)
+		store, err = data.NewStoreFromDirWithClock(deckDir, clk)
 		if err != nil {
 			fmt.Printf("Error loading decks: %v\nUsing default decks instead.\n", err)
-			store = data.NewStore() // Fallback to default store with dummy data
+			store = data.NewStoreWithClock(clk) // Fallback to default store with dummy data
+		} else {
+			watchDir = deckDir
+		}
+	}
+
+	// Override the configured scheduler if one was requested
+	if schedulerName != "" {
+		scheduler, err := srs.NewScheduler(schedulerName, store.Config.SchedulerOptions())
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
 		}
+		store.SetScheduler(scheduler)
 	}
 
 	// Initialize the main menu with the store
-	p := tea.NewProgram(ui.NewMainMenu(store), tea.WithAltScreen())
+	p := tea.NewProgram(ui.WithLiveReload(ui.NewMainMenu(store), store), tea.WithAltScreen())
+
+	// Reload the cards edited while GoCard is running
+	if watchDir != "" {
+		watcher, err := data.WatchDir(watchDir)
+		if err != nil {
+			fmt.Printf("Warning: cards changed on disk will not be reloaded: %v\n", err)
+		} else {
+			defer watcher.Close() //nolint:errcheck
+			go func() {
+				for paths := range watcher.Changes {
+					p.Send(ui.FilesChangedMsg{Paths: paths})
+				}
+			}()
+		}
+	}
 
 	// Start the program
 	if _, err := p.Run(); err != nil {
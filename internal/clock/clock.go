@@ -0,0 +1,90 @@
+// File: internal/clock/clock.go
+
+package clock
+
+import (
+	"fmt"
+	"time"
+)
+
+// EnvVar is the environment variable that sets the starting time of the clock
+const EnvVar = "GOCARD_NOW"
+
+// Clock tells the current time. Scheduling, due calculations and statistics
+// ask a Clock instead of calling time.Now so that they can be tested and
+// replayed at any date.
+type Clock interface {
+	Now() time.Time
+}
+
+// Real is the clock of the system
+type Real struct{}
+
+// Now returns the current system time
+func (Real) Now() time.Time {
+	return time.Now()
+}
+
+// Fixed is a clock that always returns the same time
+type Fixed time.Time
+
+// Now returns the fixed time
+func (f Fixed) Now() time.Time {
+	return time.Time(f)
+}
+
+// offsetClock runs at the speed of the system clock, shifted by an offset
+type offsetClock struct {
+	offset time.Duration
+}
+
+// Now returns the system time shifted by the offset
+func (o offsetClock) Now() time.Time {
+	return time.Now().Add(o.offset)
+}
+
+// StartingAt returns a clock that reads start now and keeps running from there
+func StartingAt(start time.Time) Clock {
+	return offsetClock{offset: time.Until(start)}
+}
+
+// layouts accepted by Parse, from the most to the least precise
+var layouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// Parse reads a time given as RFC 3339 or as a local date with an optional
+// time of day, e.g. "2025-03-31" or "2025-03-31T09:30"
+func Parse(value string) (time.Time, error) {
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q (expected YYYY-MM-DD, YYYY-MM-DDTHH:MM or RFC 3339)", value)
+}
+
+// StartOfDay returns the time the day containing t began in loc, for days
+// that start at dayStartHour (0-23) instead of midnight. With a day start
+// of 4, a review at 01:00 still belongs to the previous day.
+func StartOfDay(t time.Time, loc *time.Location, dayStartHour int) time.Time {
+	shifted := t.In(loc).Add(-time.Duration(dayStartHour) * time.Hour)
+	year, month, day := shifted.Date()
+	return time.Date(year, month, day, dayStartHour, 0, 0, 0, loc)
+}
+
+// DaysBetween returns the number of calendar days from the date of a to the
+// date of b, negative when b is earlier. Unlike dividing the difference by
+// 24 hours, it is not thrown off by daylight saving time changes.
+func DaysBetween(a, b time.Time) int {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	dateA := time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC)
+	dateB := time.Date(by, bm, bd, 0, 0, 0, 0, time.UTC)
+	return int(dateB.Sub(dateA).Hours() / 24)
+}
@@ -0,0 +1,129 @@
+// File: internal/clock/clock_test.go
+
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected time.Time
+	}{
+		{"2025-03-31", time.Date(2025, 3, 31, 0, 0, 0, 0, time.Local)},
+		{"2025-03-31T09:30", time.Date(2025, 3, 31, 9, 30, 0, 0, time.Local)},
+		{"2025-03-31 09:30", time.Date(2025, 3, 31, 9, 30, 0, 0, time.Local)},
+		{"2025-03-31T09:30:15", time.Date(2025, 3, 31, 9, 30, 15, 0, time.Local)},
+		{"2025-03-31T09:30:00Z", time.Date(2025, 3, 31, 9, 30, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		got, err := Parse(tc.value)
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", tc.value, err)
+			continue
+		}
+		if !got.Equal(tc.expected) {
+			t.Errorf("Parse(%q) = %v, expected %v", tc.value, got, tc.expected)
+		}
+	}
+
+	for _, value := range []string{"", "yesterday", "31/03/2025"} {
+		if _, err := Parse(value); err == nil {
+			t.Errorf("Expected an error parsing %q", value)
+		}
+	}
+}
+
+func TestFixed(t *testing.T) {
+	at := time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)
+	c := Fixed(at)
+
+	if !c.Now().Equal(at) || !c.Now().Equal(c.Now()) {
+		t.Errorf("Expected fixed clock to always return %v, got %v", at, c.Now())
+	}
+}
+
+func TestStartingAt(t *testing.T) {
+	start := time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)
+	c := StartingAt(start)
+
+	first := c.Now()
+	if diff := first.Sub(start); diff < 0 || diff > time.Second {
+		t.Errorf("Expected clock to start at %v, got %v", start, first)
+	}
+
+	// The clock keeps running
+	time.Sleep(10 * time.Millisecond)
+	if !c.Now().After(first) {
+		t.Error("Expected clock to advance")
+	}
+}
+
+func TestStartOfDay(t *testing.T) {
+	loc := time.FixedZone("UTC-5", -5*60*60)
+
+	testCases := []struct {
+		name         string
+		t            time.Time
+		dayStartHour int
+		expected     time.Time
+	}{
+		{
+			name:     "Midnight day start",
+			t:        time.Date(2025, 3, 31, 15, 30, 0, 0, loc),
+			expected: time.Date(2025, 3, 31, 0, 0, 0, 0, loc),
+		},
+		{
+			name:         "Before the day start belongs to the previous day",
+			t:            time.Date(2025, 3, 31, 2, 0, 0, 0, loc),
+			dayStartHour: 4,
+			expected:     time.Date(2025, 3, 30, 4, 0, 0, 0, loc),
+		},
+		{
+			name:         "After the day start",
+			t:            time.Date(2025, 3, 31, 4, 0, 0, 0, loc),
+			dayStartHour: 4,
+			expected:     time.Date(2025, 3, 31, 4, 0, 0, 0, loc),
+		},
+		{
+			// 02:00 UTC is still the evening of the 30th in UTC-5
+			name:     "Converted to the location",
+			t:        time.Date(2025, 3, 31, 2, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 3, 30, 0, 0, 0, 0, loc),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := StartOfDay(tc.t, loc, tc.dayStartHour)
+			if !got.Equal(tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestDaysBetween(t *testing.T) {
+	a := time.Date(2025, 3, 30, 23, 0, 0, 0, time.UTC)
+
+	if got := DaysBetween(a, time.Date(2025, 3, 31, 1, 0, 0, 0, time.UTC)); got != 1 {
+		t.Errorf("Expected 1 day to the next date, got %d", got)
+	}
+	if got := DaysBetween(a, a.AddDate(0, 0, -7)); got != -7 {
+		t.Errorf("Expected -7 days a week earlier, got %d", got)
+	}
+
+	// A day that is 23 hours long because of daylight saving time
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("Time zone data not available: %v", err)
+	}
+	before := time.Date(2025, 3, 30, 0, 0, 0, 0, berlin)
+	after := time.Date(2025, 3, 31, 0, 0, 0, 0, berlin)
+	if got := DaysBetween(before, after); got != 1 {
+		t.Errorf("Expected 1 day across a DST change, got %d", got)
+	}
+}
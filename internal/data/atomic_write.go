@@ -0,0 +1,45 @@
+// File: internal/data/atomic_write.go
+
+package data
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// writeFileAtomic writes data to a file through a temporary file in the same
+// directory that is then renamed over it, so a crash never leaves the file
+// half written. An existing file keeps its permissions, and a symlink is
+// followed so the file it points to is the one replaced.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		path = target
+	}
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tempPath := temp.Name()
+	defer os.Remove(tempPath) //nolint:errcheck // Gone once renamed
+
+	if _, err := temp.Write(data); err != nil {
+		temp.Close() //nolint:errcheck
+		return err
+	}
+	if err := temp.Sync(); err != nil {
+		temp.Close() //nolint:errcheck
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tempPath, perm); err != nil {
+		return err
+	}
+
+	return os.Rename(tempPath, path)
+}
@@ -0,0 +1,66 @@
+// File: internal/data/atomic_write_test.go
+
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "card.md")
+
+	// A new file gets the given permissions
+	if err := writeFileAtomic(path, []byte("first"), 0600); err != nil {
+		t.Fatalf("writeFileAtomic error: %v", err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Expected a new file with mode 0600, got %v (%v)", info, err)
+	}
+
+	// An existing file is replaced and keeps its permissions
+	if err := os.Chmod(path, 0640); err != nil {
+		t.Fatalf("Failed to change mode: %v", err)
+	}
+	if err := writeFileAtomic(path, []byte("second"), 0644); err != nil {
+		t.Fatalf("writeFileAtomic error: %v", err)
+	}
+	content, _ := os.ReadFile(path)
+	if string(content) != "second" {
+		t.Errorf("Expected the new content, got %q", content)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0640 {
+		t.Errorf("Expected the mode to be kept as 0640, got %v", info.Mode().Perm())
+	}
+
+	// Writing through a symlink replaces the file it points to
+	link := filepath.Join(tempDir, "link.md")
+	if err := os.Symlink(path, link); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+	if err := writeFileAtomic(link, []byte("third"), 0644); err != nil {
+		t.Fatalf("writeFileAtomic error: %v", err)
+	}
+	if target, err := os.Readlink(link); err != nil || target != path {
+		t.Errorf("Expected the symlink to be kept, got %q (%v)", target, err)
+	}
+	if content, _ := os.ReadFile(path); string(content) != "third" {
+		t.Errorf("Expected the linked file to be written, got %q", content)
+	}
+
+	// No temporary files are left behind
+	entries, err := os.ReadDir(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to read dir: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("Expected only the file and the symlink, got %d entries", len(entries))
+	}
+
+	// A missing directory is an error and creates nothing
+	if err := writeFileAtomic(filepath.Join(tempDir, "missing", "card.md"), []byte("x"), 0644); err == nil {
+		t.Error("Expected an error for a missing directory")
+	}
+}
@@ -0,0 +1,149 @@
+// File: internal/data/config.go
+
+package data
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/DavidMiserak/GoCard/internal/srs"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileName is the name of the collection configuration file,
+// kept in the root directory of the collection
+const ConfigFileName = ".gocard.yaml"
+
+// Config holds the settings of a collection. A deck directory may contain
+// its own config file to override the collection's scheduler settings and
+// daily limits.
+type Config struct {
+	Scheduler        string  `yaml:"scheduler"`         // Scheduling algorithm: "sm2" or "fsrs"
+	DesiredRetention float64 `yaml:"desired_retention"` // Target recall probability for FSRS
+	DayStartHour     int     `yaml:"day_start_hour"`    // Local hour (0-23) at which a new day starts
+	NewCardsPerDay   int     `yaml:"new_cards_per_day"` // Cards seen for the first time per deck and day
+	ReviewsPerDay    int     `yaml:"reviews_per_day"`   // Due cards reviewed per deck and day
+	Interleave       string  `yaml:"interleave"`        // Order of cards when studying all decks
+	LearningSteps    string  `yaml:"learning_steps"`    // Delays before a new card graduates, e.g. "1m 10m"
+	RelearningSteps  string  `yaml:"relearning_steps"`  // Delays before a forgotten card is reviewed in days again
+	Fuzz             bool    `yaml:"fuzz"`              // Move reviews a few days to spread out cards due together
+	LoadBalance      bool    `yaml:"load_balance"`      // Fuzz towards the day with the fewest cards due
+	LeechThreshold   int     `yaml:"leech_threshold"`   // Lapses after which a card is a leech, 0 to never
+	LeechAction      string  `yaml:"leech_action"`      // What happens to leeches: "tag" or "suspend"
+}
+
+// DefaultConfig returns the settings used when a collection has no config file
+func DefaultConfig() Config {
+	return Config{
+		Scheduler:        srs.SchedulerSM2,
+		DesiredRetention: srs.DefaultDesiredRetention,
+		NewCardsPerDay:   20,
+		ReviewsPerDay:    200,
+		Interleave:       InterleaveDeck,
+		LearningSteps:    srs.FormatSteps(srs.DefaultLearningSteps),
+		RelearningSteps:  srs.FormatSteps(srs.DefaultRelearningSteps),
+		LoadBalance:      true,
+		LeechThreshold:   8,
+		LeechAction:      LeechActionTag,
+	}
+}
+
+// LoadConfig reads the configuration of the collection rooted at dirPath.
+// Settings missing from the file, or a missing file, use the defaults.
+func LoadConfig(dirPath string) (Config, error) {
+	config, _, err := loadConfigOver(dirPath, DefaultConfig())
+	return config, err
+}
+
+// LoadDeckConfig reads the config file of a deck directory on top of the
+// collection's settings. It reports whether the deck has a config file.
+func LoadDeckConfig(deckDir string, collection Config) (Config, bool, error) {
+	return loadConfigOver(deckDir, collection)
+}
+
+// loadConfigOver reads the config file in dirPath, keeping the values of
+// base for settings the file does not mention. On error base is returned.
+func loadConfigOver(dirPath string, base Config) (Config, bool, error) {
+	path := filepath.Join(dirPath, ConfigFileName)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return base, false, nil
+		}
+		return base, false, &LoadError{Path: path, Err: fmt.Errorf("error reading config: %w", err)}
+	}
+
+	config := base
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return base, false, yamlError(path, 1, "error parsing config", err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return base, false, &LoadError{Path: path, Err: err}
+	}
+
+	return config, true, nil
+}
+
+// Validate checks that the configured values are usable
+func (c Config) Validate() error {
+	if _, err := c.NewScheduler(); err != nil {
+		return err
+	}
+
+	if c.DesiredRetention <= 0 || c.DesiredRetention >= 1 {
+		return fmt.Errorf("desired_retention must be between 0 and 1, got %v", c.DesiredRetention)
+	}
+
+	if c.DayStartHour < 0 || c.DayStartHour > 23 {
+		return fmt.Errorf("day_start_hour must be between 0 and 23, got %d", c.DayStartHour)
+	}
+
+	if c.NewCardsPerDay < 0 {
+		return fmt.Errorf("new_cards_per_day must not be negative, got %d", c.NewCardsPerDay)
+	}
+
+	if c.ReviewsPerDay < 0 {
+		return fmt.Errorf("reviews_per_day must not be negative, got %d", c.ReviewsPerDay)
+	}
+
+	if !isInterleaveMode(c.Interleave) {
+		return fmt.Errorf("unknown interleave mode %q (available: %v)", c.Interleave, InterleaveModes())
+	}
+
+	if c.LeechThreshold < 0 {
+		return fmt.Errorf("leech_threshold must not be negative, got %d", c.LeechThreshold)
+	}
+
+	if !isLeechAction(c.LeechAction) {
+		return fmt.Errorf("unknown leech action %q (available: %v)", c.LeechAction, LeechActions())
+	}
+
+	if _, err := srs.ParseSteps(c.LearningSteps); err != nil {
+		return fmt.Errorf("learning_steps: %w", err)
+	}
+
+	if _, err := srs.ParseSteps(c.RelearningSteps); err != nil {
+		return fmt.Errorf("relearning_steps: %w", err)
+	}
+
+	return nil
+}
+
+// SchedulerOptions returns the options the configured scheduler is created
+// with. Steps that do not parse are left out; Validate reports them.
+func (c Config) SchedulerOptions() srs.Options {
+	learning, _ := srs.ParseSteps(c.LearningSteps)
+	relearning, _ := srs.ParseSteps(c.RelearningSteps)
+	return srs.Options{
+		DesiredRetention: c.DesiredRetention,
+		Steps:            srs.Steps{Learning: learning, Relearning: relearning},
+		DayStartHour:     c.DayStartHour,
+	}
+}
+
+// NewScheduler creates the scheduler selected by the config
+func (c Config) NewScheduler() (srs.Scheduler, error) {
+	return srs.NewScheduler(c.Scheduler, c.SchedulerOptions())
+}
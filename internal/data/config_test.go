@@ -0,0 +1,116 @@
+// File: internal/data/config_test.go
+
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DavidMiserak/GoCard/internal/srs"
+)
+
+func TestLoadConfigDefaults(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "config-default")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir) //nolint:errcheck
+
+	config, err := LoadConfig(tempDir)
+	if err != nil {
+		t.Fatalf("LoadConfig error: %v", err)
+	}
+
+	if config != DefaultConfig() {
+		t.Errorf("Expected default config %+v, got %+v", DefaultConfig(), config)
+	}
+}
+
+func TestLoadConfigFromFile(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "config-file")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir) //nolint:errcheck
+
+	content := "scheduler: fsrs\ndesired_retention: 0.85\nday_start_hour: 4\n"
+	if err := os.WriteFile(filepath.Join(tempDir, ConfigFileName), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	config, err := LoadConfig(tempDir)
+	if err != nil {
+		t.Fatalf("LoadConfig error: %v", err)
+	}
+
+	if config.Scheduler != srs.SchedulerFSRS {
+		t.Errorf("Expected scheduler %q, got %q", srs.SchedulerFSRS, config.Scheduler)
+	}
+
+	if config.DesiredRetention != 0.85 {
+		t.Errorf("Expected desired retention 0.85, got %v", config.DesiredRetention)
+	}
+
+	if config.DayStartHour != 4 {
+		t.Errorf("Expected day start hour 4, got %d", config.DayStartHour)
+	}
+}
+
+func TestConfigLearningSteps(t *testing.T) {
+	config := DefaultConfig()
+	config.LearningSteps = "1m, 10m, 1h"
+	config.RelearningSteps = ""
+
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+
+	steps := config.SchedulerOptions().Steps
+	expected := []time.Duration{time.Minute, 10 * time.Minute, time.Hour}
+	if !reflect.DeepEqual(steps.Learning, expected) {
+		t.Errorf("Expected learning steps %v, got %v", expected, steps.Learning)
+	}
+	if len(steps.Relearning) != 0 {
+		t.Errorf("Expected no relearning steps, got %v", steps.Relearning)
+	}
+}
+
+func TestLoadConfigInvalid(t *testing.T) {
+	testCases := []string{
+		"scheduler: leitner\n",
+		"desired_retention: 1.5\n",
+		"day_start_hour: 24\n",
+		"new_cards_per_day: -1\n",
+		"reviews_per_day: -5\n",
+		"interleave: shuffle\n",
+		"learning_steps: 1m 10x\n",
+		"relearning_steps: 0m\n",
+		"leech_threshold: -1\n",
+		"leech_action: delete\n",
+		"scheduler: [not, a, string]\n",
+	}
+
+	for _, content := range testCases {
+		tempDir, err := os.MkdirTemp("", "config-invalid")
+		if err != nil {
+			t.Fatalf("Failed to create temp dir: %v", err)
+		}
+
+		if err := os.WriteFile(filepath.Join(tempDir, ConfigFileName), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+
+		config, err := LoadConfig(tempDir)
+		if err == nil {
+			t.Errorf("Expected an error for config %q", content)
+		}
+		if config != DefaultConfig() {
+			t.Errorf("Expected default config on error for %q, got %+v", content, config)
+		}
+
+		os.RemoveAll(tempDir) //nolint:errcheck
+	}
+}
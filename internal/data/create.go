@@ -0,0 +1,149 @@
+// File: internal/data/create.go
+
+package data
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+const (
+	// Longest part of a question used to name the file of a new card
+	maxFilenameLength = 50
+)
+
+// CreateDeck creates the directory of a new top-level deck in the
+// collection and adds the empty deck to the store. The directory is named
+// after the deck, with a number added if that name is already taken.
+func (s *Store) CreateDeck(name string) (model.Deck, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return model.Deck{}, fmt.Errorf("deck name is required")
+	}
+	if s.dir == "" {
+		return model.Deck{}, fmt.Errorf("no collection directory to create the deck in")
+	}
+	dirName := SanitizeFilename(name)
+	for _, deck := range s.Decks {
+		if deck.ParentID == "" && strings.EqualFold(deck.Name, dirName) {
+			return model.Deck{}, fmt.Errorf("deck already exists: %s", deck.Name)
+		}
+	}
+
+	// A collection without deck directories is a single deck, whose cards
+	// would no longer be loaded next to deck directories
+	rootIndex := s.deckIndex(s.dir)
+	if rootIndex >= 0 && len(s.Decks[rootIndex].Cards) > 0 {
+		return model.Deck{}, fmt.Errorf("the collection is a single deck: move its cards into a deck directory first")
+	}
+
+	dirPath, err := createUnique(filepath.Join(s.dir, dirName), "", func(path string) error {
+		return os.Mkdir(path, 0755)
+	})
+	if err != nil {
+		return model.Deck{}, fmt.Errorf("error creating deck directory: %w", err)
+	}
+
+	deck, err := createDeckFromDir(dirPath, s.Now(), &s.Report)
+	if err != nil {
+		return model.Deck{}, err
+	}
+	if rootIndex >= 0 {
+		s.Decks = append(s.Decks[:rootIndex:rootIndex], s.Decks[rootIndex+1:]...)
+	}
+	s.Decks = append(s.Decks, *deck)
+
+	return *deck, nil
+}
+
+// CreateCard writes a new card to a file in the directory of a deck and
+// adds it to the deck, ready to be studied. The file is named after the
+// first line of the question, with a number added if that name is already
+// taken.
+func (s *Store) CreateCard(deckID, question, answer string, tags []string) (model.Card, error) {
+	question = strings.TrimSpace(question)
+	answer = strings.TrimSpace(answer)
+	if question == "" || answer == "" {
+		return model.Card{}, fmt.Errorf("question and answer are required")
+	}
+	deckIndex := s.deckIndex(deckID)
+	if deckIndex < 0 {
+		return model.Card{}, fmt.Errorf("deck not found: %s", deckID)
+	}
+	if !isFilePath(deckID) {
+		return model.Card{}, fmt.Errorf("deck has no directory to write cards to: %s", s.Decks[deckIndex].Name)
+	}
+
+	// Reserve the file first, so that no other card is overwritten
+	base := filepath.Join(deckID, cardFilename(question))
+	path, err := createUnique(base, ".md", func(path string) error {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err != nil {
+			return err
+		}
+		return file.Close()
+	})
+	if err != nil {
+		return model.Card{}, fmt.Errorf("error creating card file: %w", err)
+	}
+
+	now := s.Now()
+	card := model.Card{
+		ID:         path,
+		DeckID:     deckID,
+		Question:   question,
+		Answer:     answer,
+		Tags:       tags,
+		Created:    now,
+		NextReview: now,
+		Ease:       2.5, // Default difficulty value
+		State:      model.StateNew,
+	}
+	if err := WriteCard(card, path); err != nil {
+		os.Remove(path) //nolint:errcheck
+		return model.Card{}, err
+	}
+
+	// Read the card back as it would be loaded
+	parsed, err := ParseMarkdownFile(path)
+	if err != nil {
+		return model.Card{}, err
+	}
+	card = parsed.ToModelCardAt(deckID, now)
+	s.Decks[deckIndex].Cards = append(s.Decks[deckIndex].Cards, card)
+
+	return card, nil
+}
+
+// cardFilename returns the name of the file of a new card, without its
+// extension, from the first line of its question
+func cardFilename(question string) string {
+	title, _, _ := strings.Cut(question, "\n")
+	title = strings.TrimSpace(strings.TrimLeft(title, "#"))
+	if runes := []rune(title); len(runes) > maxFilenameLength {
+		title = string(runes[:maxFilenameLength])
+	}
+	return strings.ToLower(SanitizeFilename(title))
+}
+
+// createUnique creates a file or directory at base+ext, or at base_2+ext,
+// base_3+ext and so on if that path is taken, and returns the path created
+func createUnique(base, ext string, create func(path string) error) (string, error) {
+	path := base + ext
+	for n := 2; ; n++ {
+		err := create(path)
+		if err == nil {
+			return path, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return "", err
+		}
+		path = fmt.Sprintf("%s_%d%s", base, n, ext)
+	}
+}
@@ -0,0 +1,129 @@
+// File: internal/data/create_test.go
+
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DavidMiserak/GoCard/internal/clock"
+)
+
+func TestCreateCard(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+
+	tempDir := t.TempDir()
+	deckDir := filepath.Join(tempDir, "go")
+	if err := os.Mkdir(deckDir, 0755); err != nil {
+		t.Fatalf("Failed to create deck dir: %v", err)
+	}
+	content := "---\ntags: [go]\n---\n# Question\nQ\n# Answer\nA\n"
+	if err := os.WriteFile(filepath.Join(deckDir, "what_is_a_goroutine-.md"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write card: %v", err)
+	}
+
+	store, err := NewStoreFromDirWithClock(tempDir, clock.Fixed(now))
+	if err != nil {
+		t.Fatalf("NewStoreFromDirWithClock error: %v", err)
+	}
+
+	// The file is named after the question, without overwriting another card
+	card, err := store.CreateCard(deckDir, "## What is a Goroutine?\nIn short", "A light thread", []string{"go", "concurrency"})
+	if err != nil {
+		t.Fatalf("CreateCard error: %v", err)
+	}
+	if expected := filepath.Join(deckDir, "what_is_a_goroutine-_2.md"); card.ID != expected {
+		t.Errorf("Expected the card in %s, got %s", expected, card.ID)
+	}
+	written, _ := os.ReadFile(filepath.Join(deckDir, "what_is_a_goroutine-.md"))
+	if string(written) != content {
+		t.Errorf("Expected the existing card to be kept, got:\n%s", written)
+	}
+
+	// The card can be studied right away and loads the same from its file
+	deck, _ := store.GetDeck(deckDir)
+	if len(deck.Cards) != 2 || !store.IsDue(card) || !card.Created.Equal(now) {
+		t.Errorf("Expected a new card due now in the deck, got %+v", card)
+	}
+	reloaded, err := NewStoreFromDirWithClock(tempDir, clock.Fixed(now))
+	if err != nil {
+		t.Fatalf("NewStoreFromDirWithClock error: %v", err)
+	}
+	if loaded, _ := reloaded.GetCard(card.ID); loaded.Question != "## What is a Goroutine?\nIn short" || strings.Join(loaded.Tags, ",") != "go,concurrency" {
+		t.Errorf("Expected the card to load from its file, got %+v", loaded)
+	}
+
+	if _, err := store.CreateCard(deckDir, "Q", " ", nil); err == nil {
+		t.Error("Expected an error creating a card without an answer")
+	}
+	if _, err := NewStore().CreateCard("deck1", "Q", "A", nil); err == nil {
+		t.Error("Expected an error creating a card in a deck without a directory")
+	}
+}
+
+func TestCreateDeck(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+
+	// An empty collection is a single empty deck, replaced by the new deck
+	tempDir := t.TempDir()
+	store, err := NewStoreFromDirWithClock(tempDir, clock.Fixed(now))
+	if err != nil {
+		t.Fatalf("NewStoreFromDirWithClock error: %v", err)
+	}
+	deck, err := store.CreateDeck("Go Basics")
+	if err != nil {
+		t.Fatalf("CreateDeck error: %v", err)
+	}
+	if deck.ID != filepath.Join(tempDir, "Go_Basics") || deck.Name != "Go_Basics" {
+		t.Errorf("Expected the deck in Go_Basics, got %+v", deck)
+	}
+	if len(store.Decks) != 1 || store.Decks[0].ID != deck.ID {
+		t.Errorf("Expected only the new deck, got %+v", store.Decks)
+	}
+	if _, err := store.CreateCard(deck.ID, "Q", "A", nil); err != nil {
+		t.Errorf("Expected cards to be added to the new deck, got %v", err)
+	}
+
+	// Names are not reused, directories that are taken get a number
+	if _, err := store.CreateDeck("go_basics"); err == nil {
+		t.Error("Expected an error creating a deck that exists")
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "Rust"), nil, 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	deck, err = store.CreateDeck("Rust")
+	if err != nil {
+		t.Fatalf("CreateDeck error: %v", err)
+	}
+	if deck.ID != filepath.Join(tempDir, "Rust_2") {
+		t.Errorf("Expected the deck in Rust_2, got %s", deck.ID)
+	}
+
+	reloaded, err := NewStoreFromDirWithClock(tempDir, clock.Fixed(now))
+	if err != nil {
+		t.Fatalf("NewStoreFromDirWithClock error: %v", err)
+	}
+	if len(reloaded.Decks) != 2 {
+		t.Errorf("Expected the new decks to load, got %+v", reloaded.Decks)
+	}
+
+	// A collection of cards without decks cannot have decks added
+	content := "---\ntags: [go]\n---\n# Question\nQ\n# Answer\nA\n"
+	singleDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(singleDir, "a.md"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write card: %v", err)
+	}
+	store, err = NewStoreFromDirWithClock(singleDir, clock.Fixed(now))
+	if err != nil {
+		t.Fatalf("NewStoreFromDirWithClock error: %v", err)
+	}
+	if _, err := store.CreateDeck("Go"); err == nil {
+		t.Error("Expected an error adding a deck to a single deck collection")
+	}
+	if _, err := NewStore().CreateDeck("Go"); err == nil {
+		t.Error("Expected an error creating a deck without a collection directory")
+	}
+}
@@ -0,0 +1,409 @@
+// File: internal/data/frontmatter.go
+
+package data
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DavidMiserak/GoCard/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// frontMatterDelimiter opens and closes the front matter of a card file
+const frontMatterDelimiter = "---"
+
+// frontMatterField is a front matter key together with the value GoCard
+// writes for it, already formatted as YAML
+type frontMatterField struct {
+	key   string
+	value string
+}
+
+// splitFrontMatter splits the content of a card file into the YAML between
+// the front matter delimiters and the body after the closing delimiter. The
+// YAML keeps its trailing newline and the body starts right after the
+// closing delimiter's line, so joinFrontMatter gives back the content as it
+// was. ok is false when the file does not start with front matter.
+func splitFrontMatter(content string) (yamlText, body string, ok bool) {
+	firstLineEnd := strings.Index(content, "\n")
+	if firstLineEnd < 0 || strings.TrimRight(content[:firstLineEnd], "\r") != frontMatterDelimiter {
+		return "", "", false
+	}
+
+	// Find the closing delimiter line
+	start := firstLineEnd + 1
+	for pos := start; pos <= len(content); {
+		lineEnd := strings.Index(content[pos:], "\n")
+		next := len(content)
+		line := content[pos:]
+		if lineEnd >= 0 {
+			line = content[pos : pos+lineEnd]
+			next = pos + lineEnd + 1
+		}
+
+		if strings.TrimRight(line, "\r") == frontMatterDelimiter {
+			return content[start:pos], content[next:], true
+		}
+
+		if lineEnd < 0 {
+			break
+		}
+		pos = next
+	}
+
+	return "", "", false
+}
+
+// joinFrontMatter puts front matter YAML and a body back together
+func joinFrontMatter(yamlText, body string) string {
+	if yamlText != "" && !strings.HasSuffix(yamlText, "\n") {
+		yamlText += "\n"
+	}
+	return frontMatterDelimiter + "\n" + yamlText + frontMatterDelimiter + "\n" + body
+}
+
+// lineEdit replaces the lines first to last (0-based, inclusive) with a single line
+type lineEdit struct {
+	first, last int
+	line        string
+}
+
+// setFrontMatterFields sets the given fields in the front matter YAML. Only
+// the lines holding those fields are rewritten: other keys, comments, key
+// order, quoting and blank lines are kept byte for byte. A field that is not
+// present yet is added after the last line of the front matter.
+func setFrontMatterFields(yamlText string, fields []frontMatterField) (string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(yamlText), &doc); err != nil {
+		return "", fmt.Errorf("error parsing frontmatter: %w", err)
+	}
+
+	var mapping *yaml.Node
+	if len(doc.Content) > 0 {
+		mapping = doc.Content[0]
+		if mapping.Kind != yaml.MappingNode {
+			return "", fmt.Errorf("frontmatter is not a mapping of keys to values")
+		}
+	}
+
+	hadTrailingNewline := strings.HasSuffix(yamlText, "\n")
+	lines := strings.Split(strings.TrimSuffix(yamlText, "\n"), "\n")
+	if yamlText == "" {
+		lines = nil
+	}
+
+	var edits []lineEdit
+	var added []string
+
+	for _, field := range fields {
+		index := mappingKeyIndex(mapping, field.key)
+		if index < 0 {
+			added = append(added, field.key+": "+field.value)
+			continue
+		}
+
+		keyNode := mapping.Content[index]
+		valueNode := mapping.Content[index+1]
+		first := keyNode.Line - 1
+		last := lastLineOfValue(mapping, index, lines)
+
+		var line string
+		if last == first && valueNode.Kind == yaml.ScalarNode && valueNode.Line == keyNode.Line && valueNode.Value != "" {
+			// Keep everything before the value: indentation, the key as it
+			// was written and the spacing after the colon
+			prefix := []rune(lines[first])[:valueNode.Column-1]
+			line = string(prefix) + quoteLike(valueNode, field.value)
+		} else {
+			// Block values and empty values are replaced by a plain value
+			indent := []rune(lines[first])[:keyNode.Column-1]
+			line = string(indent) + field.key + ": " + field.value
+		}
+		if comment := lineComment(keyNode, valueNode); comment != "" {
+			line += commentSpacing(lines[first], comment) + comment
+		}
+
+		edits = append(edits, lineEdit{first: first, last: last, line: line})
+	}
+
+	// Apply the edits from the bottom up so line numbers stay valid
+	sort.Slice(edits, func(i, j int) bool { return edits[i].first > edits[j].first })
+	for _, edit := range edits {
+		replaced := append([]string{edit.line}, lines[edit.last+1:]...)
+		lines = append(lines[:edit.first], replaced...)
+	}
+
+	lines = append(lines, added...)
+	if len(lines) == 0 {
+		return "", nil
+	}
+
+	result := strings.Join(lines, "\n")
+	if hadTrailingNewline || len(added) > 0 {
+		result += "\n"
+	}
+	return result, nil
+}
+
+// mappingKeyIndex returns the index of the key node with the given name in
+// a mapping's content, or -1 when the key is not present
+func mappingKeyIndex(mapping *yaml.Node, key string) int {
+	if mapping == nil {
+		return -1
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// lastLineOfValue returns the 0-based index of the last line taken by the
+// value of the key at index in the mapping. The value ends before the next
+// key, not counting the blank lines and comments in between.
+func lastLineOfValue(mapping *yaml.Node, index int, lines []string) int {
+	first := mapping.Content[index].Line - 1
+
+	end := len(lines)
+	if index+2 < len(mapping.Content) {
+		end = mapping.Content[index+2].Line - 1
+	}
+
+	last := end - 1
+	for last > first {
+		trimmed := strings.TrimSpace(lines[last])
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			break
+		}
+		last--
+	}
+
+	return last
+}
+
+// lineComment returns the comment at the end of a key's line, which the
+// YAML parser attaches to either the key or the value
+func lineComment(keyNode, valueNode *yaml.Node) string {
+	if valueNode.LineComment != "" {
+		return valueNode.LineComment
+	}
+	return keyNode.LineComment
+}
+
+// commentSpacing returns the whitespace written before a comment on the
+// original line, or a single space if there was none
+func commentSpacing(original, comment string) string {
+	index := strings.LastIndex(original, comment)
+	if index < 0 {
+		return " "
+	}
+
+	before := original[:index]
+	spacing := before[len(strings.TrimRight(before, " \t")):]
+	if spacing == "" {
+		return " "
+	}
+	return spacing
+}
+
+// quoteLike formats a value with the same quoting as the scalar it replaces
+func quoteLike(node *yaml.Node, value string) string {
+	switch {
+	case node.Style&yaml.DoubleQuotedStyle != 0:
+		return `"` + value + `"`
+	case node.Style&yaml.SingleQuotedStyle != 0:
+		return "'" + value + "'"
+	default:
+		return value
+	}
+}
+
+// updateFrontMatterFields sets the SRS-related fields in the YAML of a card's
+// front matter, leaving everything else as it is. Fields missing from the
+// front matter are added, so a card's schedule is saved even if the file was
+// written without one. Cards that were never reviewed have no schedule to
+// save, but may still be suspended or buried.
+func updateFrontMatterFields(frontMatter string, card model.Card) (string, error) {
+	var existing struct {
+		LastReviewed any        `yaml:"last_reviewed"`
+		Tags         []string   `yaml:"tags"`
+		Lapses       *int       `yaml:"lapses"`
+		Suspended    *bool      `yaml:"suspended"`
+		BuriedUntil  *time.Time `yaml:"buried_until"`
+	}
+	if err := yaml.Unmarshal([]byte(frontMatter), &existing); err != nil {
+		return "", fmt.Errorf("error parsing frontmatter: %w", err)
+	}
+
+	var fields []frontMatterField
+	var removed []string
+
+	if !card.LastReviewed.IsZero() {
+		fields = append(fields,
+			frontMatterField{"last_reviewed", formatTimestamp(card.LastReviewed)},
+			frontMatterField{"next_review", formatTimestamp(card.NextReview)},
+			frontMatterField{"last_rating", strconv.Itoa(card.Rating)},
+			frontMatterField{"review_interval", strconv.Itoa(card.Interval)},
+			frontMatterField{"difficulty", formatEase(card.Ease)},
+			frontMatterField{"state", frontMatterState(card)},
+			frontMatterField{"step", strconv.Itoa(card.Step)},
+		)
+
+		// The FSRS memory state is written once the card has one
+		if card.Stability > 0 {
+			fields = append(fields,
+				frontMatterField{"stability", fmt.Sprintf("%.4f", card.Stability)},
+				frontMatterField{"fsrs_difficulty", fmt.Sprintf("%.4f", card.Difficulty)},
+			)
+		}
+	} else if existing.LastReviewed != nil {
+		// The only review of the card was undone: back to a new card
+		removed = append(removed, "last_reviewed", "next_review", "last_rating", "state", "step", "stability", "fsrs_difficulty")
+		fields = append(fields,
+			frontMatterField{"review_interval", strconv.Itoa(card.Interval)},
+			frontMatterField{"difficulty", formatEase(card.Ease)},
+		)
+	}
+
+	// Lapses are written once the card has some, and kept up to date when
+	// the file already has them
+	if card.Lapses > 0 || existing.Lapses != nil {
+		fields = append(fields, frontMatterField{"lapses", strconv.Itoa(card.Lapses)})
+	}
+
+	// Suspension and burial are only in the file while they apply
+	switch {
+	case card.Suspended:
+		fields = append(fields, frontMatterField{"suspended", "true"})
+	case existing.Suspended != nil:
+		removed = append(removed, "suspended")
+	}
+	switch {
+	case !card.BuriedUntil.IsZero():
+		fields = append(fields, frontMatterField{"buried_until", formatTimestamp(card.BuriedUntil)})
+	case existing.BuriedUntil != nil:
+		removed = append(removed, "buried_until")
+	}
+
+	// The tags are only rewritten to add or remove the leech tag, as when
+	// the rating that made the card a leech is undone, keeping the way they
+	// were written otherwise
+	switch hasLeechTag := containsString(existing.Tags, model.LeechTag); {
+	case card.IsLeech() && !hasLeechTag:
+		tags := append(existing.Tags, model.LeechTag)
+		fields = append(fields, frontMatterField{"tags", formatFlowList(tags)})
+	case !card.IsLeech() && hasLeechTag:
+		var tags []string
+		for _, tag := range existing.Tags {
+			if tag != model.LeechTag {
+				tags = append(tags, tag)
+			}
+		}
+		fields = append(fields, frontMatterField{"tags", formatFlowList(tags)})
+	}
+
+	if len(fields) == 0 && len(removed) == 0 {
+		return frontMatter, nil
+	}
+
+	updated, err := setFrontMatterFields(frontMatter, fields)
+	if err != nil {
+		return "", err
+	}
+	return removeFrontMatterFields(updated, removed)
+}
+
+// removeFrontMatterFields removes the given keys and their values from the
+// front matter YAML, leaving every other line as it is
+func removeFrontMatterFields(yamlText string, keys []string) (string, error) {
+	if len(keys) == 0 {
+		return yamlText, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(yamlText), &doc); err != nil {
+		return "", fmt.Errorf("error parsing frontmatter: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return yamlText, nil
+	}
+	mapping := doc.Content[0]
+
+	hadTrailingNewline := strings.HasSuffix(yamlText, "\n")
+	lines := strings.Split(strings.TrimSuffix(yamlText, "\n"), "\n")
+
+	var edits []lineEdit
+	for _, key := range keys {
+		if index := mappingKeyIndex(mapping, key); index >= 0 {
+			edits = append(edits, lineEdit{first: mapping.Content[index].Line - 1, last: lastLineOfValue(mapping, index, lines)})
+		}
+	}
+
+	// Remove the lines from the bottom up so line numbers stay valid
+	sort.Slice(edits, func(i, j int) bool { return edits[i].first > edits[j].first })
+	for _, edit := range edits {
+		lines = append(lines[:edit.first], lines[edit.last+1:]...)
+	}
+
+	if len(lines) == 0 {
+		return "", nil
+	}
+	result := strings.Join(lines, "\n")
+	if hadTrailingNewline {
+		result += "\n"
+	}
+	return result, nil
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// formatFlowList formats strings as a YAML flow sequence such as [go, "a, b"],
+// quoting the ones that would not read back as plain strings
+func formatFlowList(values []string) string {
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		formatted[i] = value
+		if value == "" || value != strings.TrimSpace(value) || strings.ContainsAny(value, ",[]{}:#&*!|>'\"%@`") {
+			formatted[i] = strconv.Quote(value)
+		}
+	}
+	return "[" + strings.Join(formatted, ", ") + "]"
+}
+
+// frontMatterState returns the state written to a card's front matter:
+// empty for cards never reviewed, which are new, and the card's state
+// otherwise. Reviewed cards loaded without a state are review cards.
+func frontMatterState(card model.Card) string {
+	switch {
+	case card.LastReviewed.IsZero():
+		return ""
+	case card.State == model.StateNew:
+		return model.StateReview.String()
+	default:
+		return card.State.String()
+	}
+}
+
+// formatTimestamp formats a review time for the front matter
+func formatTimestamp(t time.Time) string {
+	return t.Truncate(time.Second).Format(time.RFC3339)
+}
+
+// formatEase formats an ease factor with at most two decimals, which keeps
+// the 0.15 steps of SM-2 exact
+func formatEase(ease float64) string {
+	return strconv.FormatFloat(math.Round(ease*100)/100, 'f', -1, 64)
+}
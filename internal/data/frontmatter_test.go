@@ -0,0 +1,200 @@
+// File: internal/data/frontmatter_test.go
+
+package data
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata")
+
+// reviewedCard returns a card as it is after a review on 2025-03-31
+func reviewedCard() model.Card {
+	reviewed := time.Date(2025, 3, 31, 9, 30, 0, 0, time.UTC)
+	return model.Card{
+		LastReviewed: reviewed,
+		NextReview:   reviewed.AddDate(0, 0, 4),
+		Rating:       4,
+		Interval:     4,
+		Ease:         2.35,
+		State:        model.StateReview,
+	}
+}
+
+func TestUpdateFrontMatterGolden(t *testing.T) {
+	fsrsCard := reviewedCard()
+	fsrsCard.Stability = 4.25
+	fsrsCard.Difficulty = 6.1
+
+	// Forgotten and back at the first relearning step
+	relearningCard := reviewedCard()
+	relearningCard.NextReview = relearningCard.LastReviewed.Add(10 * time.Minute)
+	relearningCard.Rating = 1
+	relearningCard.Interval = 1
+	relearningCard.Ease = 2.3
+	relearningCard.State = model.StateRelearning
+
+	// Forgotten for the eighth time and suspended as a leech
+	leechCard := relearningCard
+	leechCard.Ease = 1.3
+	leechCard.Lapses = 8
+	leechCard.Tags = []string{"chemistry", "organic chemistry", model.LeechTag}
+	leechCard.Suspended = true
+
+	testCases := []struct {
+		name string
+		card model.Card
+	}{
+		{"unknown_keys", reviewedCard()},
+		{"missing_fields", reviewedCard()},
+		{"quoted_and_block", reviewedCard()},
+		{"fsrs", fsrsCard},
+		{"relearning", relearningCard},
+		{"leech", leechCard},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			inputPath := filepath.Join("testdata", "frontmatter", tc.name+".md")
+			goldenPath := filepath.Join("testdata", "frontmatter", tc.name+".golden")
+
+			input, err := os.ReadFile(inputPath)
+			if err != nil {
+				t.Fatalf("Failed to read input: %v", err)
+			}
+
+			frontMatter, body, ok := splitFrontMatter(string(input))
+			if !ok {
+				t.Fatalf("Expected %s to have front matter", inputPath)
+			}
+			if joinFrontMatter(frontMatter, body) != string(input) {
+				t.Fatalf("Expected splitting and joining to give back the input")
+			}
+
+			updated, err := updateFrontMatterFields(frontMatter, tc.card)
+			if err != nil {
+				t.Fatalf("updateFrontMatterFields error: %v", err)
+			}
+			got := joinFrontMatter(updated, body)
+
+			if *updateGolden {
+				if err := os.WriteFile(goldenPath, []byte(got), 0644); err != nil {
+					t.Fatalf("Failed to update golden file: %v", err)
+				}
+			}
+
+			expected, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("Failed to read golden file: %v", err)
+			}
+			if got != string(expected) {
+				t.Errorf("Updated card does not match %s:\n%s", goldenPath, got)
+			}
+
+			// Saving the same schedule again must not change anything
+			again, err := updateFrontMatterFields(updated, tc.card)
+			if err != nil {
+				t.Fatalf("updateFrontMatterFields error: %v", err)
+			}
+			if again != updated {
+				t.Errorf("Expected a second update to be a no-op, got:\n%s", again)
+			}
+
+			// The result must still parse, with the schedule of the card
+			path := filepath.Join(t.TempDir(), tc.name+".md")
+			if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+				t.Fatalf("Failed to write card: %v", err)
+			}
+			parsed, err := ParseMarkdownFile(path)
+			if err != nil {
+				t.Fatalf("ParseMarkdownFile error: %v", err)
+			}
+			card := parsed.ToModelCard("deck")
+			if !card.NextReview.Equal(tc.card.NextReview) || card.Rating != tc.card.Rating || card.Ease != tc.card.Ease {
+				t.Errorf("Expected the saved schedule to be read back, got %+v", card)
+			}
+		})
+	}
+}
+
+func TestUpdateFrontMatterUnreviewedCard(t *testing.T) {
+	frontMatter := "tags: [go]\nsource: wiki\n"
+
+	updated, err := updateFrontMatterFields(frontMatter, model.Card{Interval: 1})
+	if err != nil {
+		t.Fatalf("updateFrontMatterFields error: %v", err)
+	}
+	if updated != frontMatter {
+		t.Errorf("Expected front matter of an unreviewed card to be unchanged, got:\n%s", updated)
+	}
+}
+
+func TestSetFrontMatterFieldsErrors(t *testing.T) {
+	fields := []frontMatterField{{"review_interval", "1"}}
+
+	if _, err := setFrontMatterFields("- a\n- b\n", fields); err == nil {
+		t.Error("Expected an error for front matter that is not a mapping")
+	}
+	if _, err := setFrontMatterFields("tags: [unclosed\n", fields); err == nil {
+		t.Error("Expected an error for invalid YAML")
+	}
+
+	// Empty front matter simply gets the fields
+	got, err := setFrontMatterFields("", fields)
+	if err != nil || got != "review_interval: 1\n" {
+		t.Errorf("Expected the field to be added to empty front matter, got %q (%v)", got, err)
+	}
+}
+
+func TestUpdateCardFilePreservesUnknownKeys(t *testing.T) {
+	input, err := os.ReadFile(filepath.Join("testdata", "frontmatter", "unknown_keys.md"))
+	if err != nil {
+		t.Fatalf("Failed to read input: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "card.md")
+	if err := os.WriteFile(path, input, 0644); err != nil {
+		t.Fatalf("Failed to write card: %v", err)
+	}
+
+	parsed, err := ParseMarkdownFile(path)
+	if err != nil {
+		t.Fatalf("ParseMarkdownFile error: %v", err)
+	}
+
+	// Rescheduling the card matches the golden file exactly
+	card := reviewedCard()
+	card.ID = path
+	card.Question = parsed.Question
+	card.Answer = parsed.Answer
+	if err := UpdateCardFile(card); err != nil {
+		t.Fatalf("UpdateCardFile error: %v", err)
+	}
+
+	expected, err := os.ReadFile(filepath.Join("testdata", "frontmatter", "unknown_keys.golden"))
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+	got, _ := os.ReadFile(path)
+	if string(got) != string(expected) {
+		t.Errorf("Expected UpdateCardFile to match the golden file, got:\n%s", got)
+	}
+
+	// Editing the answer rewrites the body but keeps the front matter
+	card.Answer = "It blocks until both sides are ready."
+	if err := UpdateCardFile(card); err != nil {
+		t.Fatalf("UpdateCardFile error: %v", err)
+	}
+	got, _ = os.ReadFile(path)
+	if !strings.Contains(string(got), "author:   Jane Doe   # original author") ||
+		!strings.Contains(string(got), card.Answer) {
+		t.Errorf("Expected front matter to be kept and the answer updated, got:\n%s", got)
+	}
+}
@@ -0,0 +1,179 @@
+// File: internal/data/history.go
+
+package data
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+// HistoryFileName is the name of the review log kept in each deck directory
+const HistoryFileName = ".gocard-history.jsonl"
+
+// historyRecord is the on-disk representation of a review event.
+// Card paths are stored relative to the deck directory so the log
+// stays valid when the collection is moved or cloned elsewhere.
+type historyRecord struct {
+	Card         string    `json:"card"`
+	Timestamp    time.Time `json:"timestamp"`
+	Rating       int       `json:"rating"`
+	PrevInterval int       `json:"prev_interval"`
+	NewInterval  int       `json:"new_interval"`
+	PrevEase     float64   `json:"prev_ease"`
+	NewEase      float64   `json:"new_ease"`
+	TimeToAnswer int64     `json:"time_to_answer_ms"`
+}
+
+// historyPath returns the path of the review log for a deck directory
+func historyPath(deckDir string) string {
+	return filepath.Join(deckDir, HistoryFileName)
+}
+
+// AppendReviewEvent appends a review event to the deck's review log,
+// creating the log if it does not exist yet
+func AppendReviewEvent(deckDir string, event model.ReviewEvent) error {
+	line, err := encodeHistoryRecord(deckDir, event)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(historyPath(deckDir), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening review history: %w", err)
+	}
+
+	if _, err := file.Write(line); err != nil {
+		file.Close() //nolint:errcheck
+		return fmt.Errorf("error writing review history: %w", err)
+	}
+
+	return file.Close()
+}
+
+// LoadReviewHistory reads all review events recorded for a deck directory.
+// A deck without a review log has no history and is not an error.
+func LoadReviewHistory(deckDir string) ([]model.ReviewEvent, error) {
+	file, err := os.Open(historyPath(deckDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error opening review history: %w", err)
+	}
+	defer file.Close() //nolint:errcheck
+
+	var events []model.ReviewEvent
+	scanner := bufio.NewScanner(file)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var record historyRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			return events, &LoadError{Path: historyPath(deckDir), Line: lineNum, Err: fmt.Errorf("error parsing review history: %w", err)}
+		}
+
+		events = append(events, decodeHistoryRecord(deckDir, record))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return events, &LoadError{Path: historyPath(deckDir), Err: fmt.Errorf("error scanning review history: %w", err)}
+	}
+
+	return events, nil
+}
+
+// RemoveReviewEvent removes the latest record of a review event from the
+// deck's review log, rewriting the log without it. A review that was never
+// logged is not an error.
+func RemoveReviewEvent(deckDir string, event model.ReviewEvent) error {
+	content, err := os.ReadFile(historyPath(deckDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("error reading review history: %w", err)
+	}
+
+	lines := strings.SplitAfter(string(content), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		var record historyRecord
+		if err := json.Unmarshal([]byte(lines[i]), &record); err != nil {
+			continue // Leave lines that cannot be read as they are
+		}
+		if !sameReview(decodeHistoryRecord(deckDir, record), event) {
+			continue
+		}
+
+		updated := strings.Join(append(lines[:i:i], lines[i+1:]...), "")
+		if err := writeFileAtomic(historyPath(deckDir), []byte(updated), 0644); err != nil {
+			return fmt.Errorf("error writing review history: %w", err)
+		}
+		return nil
+	}
+
+	return nil
+}
+
+// sameReview reports whether two review events record the same review
+func sameReview(a, b model.ReviewEvent) bool {
+	return a.CardID == b.CardID && a.Timestamp.Equal(b.Timestamp) && a.Rating == b.Rating
+}
+
+// encodeHistoryRecord converts a review event into a single JSON line
+func encodeHistoryRecord(deckDir string, event model.ReviewEvent) ([]byte, error) {
+	cardPath := event.CardID
+	if rel, err := filepath.Rel(deckDir, event.CardID); err == nil {
+		cardPath = filepath.ToSlash(rel)
+	}
+
+	record := historyRecord{
+		Card:         cardPath,
+		Timestamp:    event.Timestamp,
+		Rating:       event.Rating,
+		PrevInterval: event.PrevInterval,
+		NewInterval:  event.NewInterval,
+		PrevEase:     event.PrevEase,
+		NewEase:      event.NewEase,
+		TimeToAnswer: event.TimeToAnswer.Milliseconds(),
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding review event: %w", err)
+	}
+
+	return append(line, '\n'), nil
+}
+
+// decodeHistoryRecord converts an on-disk record back into a review event
+func decodeHistoryRecord(deckDir string, record historyRecord) model.ReviewEvent {
+	cardID := filepath.FromSlash(record.Card)
+	if !filepath.IsAbs(cardID) {
+		cardID = filepath.Join(deckDir, cardID)
+	}
+
+	return model.ReviewEvent{
+		CardID:       cardID,
+		DeckID:       deckDir,
+		Timestamp:    record.Timestamp,
+		Rating:       record.Rating,
+		PrevInterval: record.PrevInterval,
+		NewInterval:  record.NewInterval,
+		PrevEase:     record.PrevEase,
+		NewEase:      record.NewEase,
+		TimeToAnswer: time.Duration(record.TimeToAnswer) * time.Millisecond,
+	}
+}
@@ -0,0 +1,274 @@
+// File: internal/data/history_test.go
+
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DavidMiserak/GoCard/internal/clock"
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+func TestAppendAndLoadReviewHistory(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "history-append")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir) //nolint:errcheck
+
+	reviewedAt := time.Date(2025, 3, 22, 10, 30, 0, 0, time.UTC)
+	events := []model.ReviewEvent{
+		{
+			CardID:       filepath.Join(tempDir, "card1.md"),
+			DeckID:       tempDir,
+			Timestamp:    reviewedAt,
+			Rating:       4,
+			PrevInterval: 1,
+			NewInterval:  3,
+			PrevEase:     2.5,
+			NewEase:      2.5,
+			TimeToAnswer: 4200 * time.Millisecond,
+		},
+		{
+			CardID:       filepath.Join(tempDir, "card2.md"),
+			DeckID:       tempDir,
+			Timestamp:    reviewedAt.Add(time.Minute),
+			Rating:       1,
+			PrevInterval: 6,
+			NewInterval:  1,
+			PrevEase:     2.5,
+			NewEase:      2.2,
+			TimeToAnswer: 12 * time.Second,
+		},
+	}
+
+	for _, event := range events {
+		if err := AppendReviewEvent(tempDir, event); err != nil {
+			t.Fatalf("AppendReviewEvent error: %v", err)
+		}
+	}
+
+	// The log must store card paths relative to the deck directory
+	content, err := os.ReadFile(filepath.Join(tempDir, HistoryFileName))
+	if err != nil {
+		t.Fatalf("Failed to read history file: %v", err)
+	}
+	if strings.Contains(string(content), tempDir) {
+		t.Errorf("Expected history to contain relative card paths, got:\n%s", content)
+	}
+	if lines := strings.Count(string(content), "\n"); lines != 2 {
+		t.Errorf("Expected 2 lines in history file, got %d", lines)
+	}
+
+	loaded, err := LoadReviewHistory(tempDir)
+	if err != nil {
+		t.Fatalf("LoadReviewHistory error: %v", err)
+	}
+
+	if len(loaded) != len(events) {
+		t.Fatalf("Expected %d events, got %d", len(events), len(loaded))
+	}
+
+	for i, event := range events {
+		got := loaded[i]
+		if got.CardID != event.CardID {
+			t.Errorf("Event %d: expected card ID %s, got %s", i, event.CardID, got.CardID)
+		}
+		if got.DeckID != event.DeckID {
+			t.Errorf("Event %d: expected deck ID %s, got %s", i, event.DeckID, got.DeckID)
+		}
+		if !got.Timestamp.Equal(event.Timestamp) {
+			t.Errorf("Event %d: expected timestamp %v, got %v", i, event.Timestamp, got.Timestamp)
+		}
+		if got.Rating != event.Rating || got.PrevInterval != event.PrevInterval || got.NewInterval != event.NewInterval {
+			t.Errorf("Event %d: expected %+v, got %+v", i, event, got)
+		}
+		if got.PrevEase != event.PrevEase || got.NewEase != event.NewEase {
+			t.Errorf("Event %d: expected ease %.2f->%.2f, got %.2f->%.2f",
+				i, event.PrevEase, event.NewEase, got.PrevEase, got.NewEase)
+		}
+		if got.TimeToAnswer != event.TimeToAnswer {
+			t.Errorf("Event %d: expected time to answer %v, got %v", i, event.TimeToAnswer, got.TimeToAnswer)
+		}
+	}
+}
+
+func TestLoadReviewHistoryMissingFile(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "history-missing")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir) //nolint:errcheck
+
+	events, err := LoadReviewHistory(tempDir)
+	if err != nil {
+		t.Fatalf("Expected no error for missing history, got %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("Expected no events, got %d", len(events))
+	}
+}
+
+func TestSaveCardReviewRecordsHistory(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "history-store")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir) //nolint:errcheck
+
+	cardPath := filepath.Join(tempDir, "card.md")
+
+	store := &Store{
+		Decks: []model.Deck{
+			{
+				ID:   tempDir,
+				Name: "Test Deck",
+				Cards: []model.Card{
+					{ID: cardPath, DeckID: tempDir, Ease: 2.5, Interval: 1},
+				},
+			},
+		},
+	}
+
+	card := store.Decks[0].Cards[0]
+	if err := store.SaveCardReviewWithTime(card, 4, 3*time.Second); err != nil {
+		t.Fatalf("SaveCardReviewWithTime error: %v", err)
+	}
+
+	history := store.GetCardHistory(cardPath)
+	if len(history) != 1 {
+		t.Fatalf("Expected 1 history event, got %d", len(history))
+	}
+	if history[0].PrevInterval != 1 || history[0].NewInterval != 3 {
+		t.Errorf("Expected interval 1 -> 3, got %d -> %d", history[0].PrevInterval, history[0].NewInterval)
+	}
+
+	// The review must also have been appended to the deck's log
+	loaded, err := LoadReviewHistory(tempDir)
+	if err != nil {
+		t.Fatalf("LoadReviewHistory error: %v", err)
+	}
+	if len(loaded) != 1 || loaded[0].Rating != 4 || loaded[0].TimeToAnswer != 3*time.Second {
+		t.Errorf("Expected one logged review rated 4 taking 3s, got %+v", loaded)
+	}
+
+	// A log that cannot be written to is reported, the review is kept
+	if err := os.Remove(historyPath(tempDir)); err != nil {
+		t.Fatalf("Failed to remove review history: %v", err)
+	}
+	if err := os.Mkdir(historyPath(tempDir), 0755); err != nil {
+		t.Fatalf("Failed to block review history: %v", err)
+	}
+	card, _ = store.GetCard(cardPath)
+	if err := store.SaveCardReview(card, 4); err == nil || !strings.Contains(err.Error(), "error saving review history") {
+		t.Errorf("Expected an error saving the review history, got %v", err)
+	}
+	if len(store.GetCardHistory(cardPath)) != 2 {
+		t.Error("Expected the review to be kept in memory")
+	}
+}
+
+func TestUndoCardReview(t *testing.T) {
+	tempDir := t.TempDir()
+	cardPath := filepath.Join(tempDir, "card.md")
+	otherPath := filepath.Join(tempDir, "other.md")
+
+	store := &Store{
+		Decks: []model.Deck{
+			{
+				ID:   tempDir,
+				Name: "Test Deck",
+				Cards: []model.Card{
+					{ID: cardPath, DeckID: tempDir, Ease: 2.5, Interval: 10, State: model.StateReview},
+					{ID: otherPath, DeckID: tempDir, Ease: 2.5, Interval: 1},
+				},
+			},
+		},
+	}
+	store.SetClock(clock.Fixed(time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)))
+
+	// Review the card twice, with another card in between
+	card := store.Decks[0].Cards[0]
+	if err := store.SaveCardReview(card, 4); err != nil {
+		t.Fatalf("SaveCardReview error: %v", err)
+	}
+	if err := store.SaveCardReview(store.Decks[0].Cards[1], 4); err != nil {
+		t.Fatalf("SaveCardReview error: %v", err)
+	}
+	reviewed, _ := store.GetCard(cardPath)
+	if err := store.SaveCardReview(reviewed, 1); err != nil {
+		t.Fatalf("SaveCardReview error: %v", err)
+	}
+
+	// Undoing the blackout restores the card and removes only that review
+	if err := store.UndoCardReview(reviewed); err != nil {
+		t.Fatalf("UndoCardReview error: %v", err)
+	}
+	if got, _ := store.GetCard(cardPath); !reflect.DeepEqual(got, reviewed) {
+		t.Errorf("Expected the card as it was before the review, got %+v", got)
+	}
+	if history := store.GetReviewHistory(); len(history) != 2 || history[0].Rating != 4 || history[1].CardID != otherPath {
+		t.Errorf("Expected the first two reviews to be kept, got %+v", history)
+	}
+	loaded, err := LoadReviewHistory(tempDir)
+	if err != nil {
+		t.Fatalf("LoadReviewHistory error: %v", err)
+	}
+	if len(loaded) != 2 || loaded[0].CardID != cardPath || loaded[1].CardID != otherPath {
+		t.Errorf("Expected the review to be removed from the log, got %+v", loaded)
+	}
+
+	// Undoing the first review goes back to the original card
+	if err := store.UndoCardReview(card); err != nil {
+		t.Fatalf("UndoCardReview error: %v", err)
+	}
+	if loaded, _ := LoadReviewHistory(tempDir); len(loaded) != 1 || loaded[0].CardID != otherPath {
+		t.Errorf("Expected only the other card's review in the log, got %+v", loaded)
+	}
+
+	if err := store.UndoCardReview(model.Card{ID: "missing", DeckID: tempDir}); err == nil {
+		t.Error("Expected an error for a card that is not in the store")
+	}
+}
+
+func TestUndoCardReviewSaveError(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "a.md")
+	if err := os.WriteFile(path, []byte("---\ntags: [go]\n---\n# Question\nQ\n# Answer\nA\n"), 0644); err != nil {
+		t.Fatalf("Failed to write card: %v", err)
+	}
+	store, err := NewStoreFromDirWithClock(tempDir, clock.Fixed(now))
+	if err != nil {
+		t.Fatalf("NewStoreFromDirWithClock error: %v", err)
+	}
+	card, _ := store.GetCard(path)
+	if err := store.SaveCardReview(card, 4); err != nil {
+		t.Fatalf("SaveCardReview error: %v", err)
+	}
+
+	// The card file can no longer be written
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("Failed to remove card: %v", err)
+	}
+	if err := os.Mkdir(path, 0755); err != nil {
+		t.Fatalf("Failed to create dir: %v", err)
+	}
+
+	// The error is reported, but the review is still taken out of the history
+	if err := store.UndoCardReview(card); err == nil {
+		t.Error("Expected an error writing the card file")
+	}
+	if history := store.GetReviewHistory(); len(history) != 0 {
+		t.Errorf("Expected the review to be removed from the history, got %+v", history)
+	}
+	if loaded, _ := LoadReviewHistory(tempDir); len(loaded) != 0 {
+		t.Errorf("Expected the review to be removed from the log, got %+v", loaded)
+	}
+}
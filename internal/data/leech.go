@@ -0,0 +1,67 @@
+// File: internal/data/leech.go
+
+package data
+
+import (
+	"sort"
+
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+// Leech actions, setting what happens to a card that becomes a leech
+const (
+	LeechActionTag     = "tag"     // Tag the card as a leech
+	LeechActionSuspend = "suspend" // Tag the card and suspend it
+)
+
+// LeechActions returns the names of the available leech actions
+func LeechActions() []string {
+	return []string{LeechActionTag, LeechActionSuspend}
+}
+
+// isLeechAction reports whether action is a known leech action. An empty
+// action only tags leeches.
+func isLeechAction(action string) bool {
+	for _, known := range LeechActions() {
+		if action == known {
+			return true
+		}
+	}
+	return action == ""
+}
+
+// markLeech tags a card that was just forgotten as a leech once its lapses
+// reach the leech threshold of its deck, suspending it if the deck's
+// settings ask for it. previous is the card before the review.
+func (s *Store) markLeech(previous, card model.Card) model.Card {
+	config := s.ConfigForDeck(card.DeckID)
+	if config.LeechThreshold <= 0 || card.Lapses <= previous.Lapses || card.Lapses < config.LeechThreshold {
+		return card
+	}
+
+	if !card.IsLeech() {
+		card.Tags = append(append([]string(nil), card.Tags...), model.LeechTag)
+	}
+	if config.LeechAction == LeechActionSuspend {
+		card.Suspended = true
+	}
+	return card
+}
+
+// Leeches returns the cards tagged as leeches in every deck, the most often
+// forgotten first
+func (s *Store) Leeches() []model.Card {
+	var leeches []model.Card
+	for _, deck := range s.Decks {
+		for _, card := range deck.Cards {
+			if card.IsLeech() {
+				leeches = append(leeches, card)
+			}
+		}
+	}
+
+	sort.SliceStable(leeches, func(i, j int) bool {
+		return leeches[i].Lapses > leeches[j].Lapses
+	})
+	return leeches
+}
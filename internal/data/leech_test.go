@@ -0,0 +1,125 @@
+// File: internal/data/leech_test.go
+
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DavidMiserak/GoCard/internal/clock"
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+func TestLeechDetection(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	review := func(id string, lapses int) model.Card {
+		return model.Card{
+			ID: id, DeckID: "deck", LastReviewed: now.AddDate(0, 0, -5), NextReview: now,
+			Interval: 5, Ease: 2.5, State: model.StateReview, Lapses: lapses, Tags: []string{"go"},
+		}
+	}
+
+	config := DefaultConfig()
+	config.LeechThreshold = 3
+	store := &Store{
+		Config: config,
+		Decks:  []model.Deck{{ID: "deck", Cards: []model.Card{review("card", 2), review("other", 1)}}},
+	}
+	store.SetClock(clock.Fixed(now))
+
+	// Remembering a card does not make it a leech
+	if err := store.SaveCardReview(review("card", 2), 4); err != nil {
+		t.Fatalf("SaveCardReview error: %v", err)
+	}
+	if card, _ := store.GetCard("card"); card.Lapses != 2 || card.IsLeech() {
+		t.Fatalf("Expected 2 lapses and no leech tag after a good review, got %+v", card)
+	}
+
+	// The lapse reaching the threshold tags the card, keeping its tags
+	if err := store.SaveCardReview(review("card", 2), 1); err != nil {
+		t.Fatalf("SaveCardReview error: %v", err)
+	}
+	card, _ := store.GetCard("card")
+	if card.Lapses != 3 || !card.IsLeech() || !card.HasTag("go") {
+		t.Fatalf("Expected a leech with 3 lapses keeping its tags, got %+v", card)
+	}
+	if card.Suspended {
+		t.Errorf("Expected the leech not to be suspended with the tag action")
+	}
+
+	// The suspend action also leaves the card out of study sessions
+	store.Config.LeechAction = LeechActionSuspend
+	store.Config.LeechThreshold = 2
+	if err := store.SaveCardReview(review("other", 1), 2); err != nil {
+		t.Fatalf("SaveCardReview error: %v", err)
+	}
+	other, _ := store.GetCard("other")
+	if !other.IsLeech() || !other.Suspended {
+		t.Fatalf("Expected a suspended leech, got %+v", other)
+	}
+	store.SetClock(clock.Fixed(now.AddDate(1, 0, 0)))
+	for _, queued := range store.StudyQueue("deck") {
+		if queued.ID == "other" {
+			t.Errorf("Expected the suspended leech to be left out of the study queue")
+		}
+	}
+
+	// Leeches are listed most lapses first
+	leeches := cardIDs(store.Leeches())
+	if !equalIDs(leeches, []string{"card", "other"}) {
+		t.Errorf("Expected leeches [card other], got %v", leeches)
+	}
+}
+
+func TestUndoLeechReview(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+
+	tempDir := t.TempDir()
+	deckDir := filepath.Join(tempDir, "go")
+	if err := os.Mkdir(deckDir, 0755); err != nil {
+		t.Fatalf("Failed to create deck dir: %v", err)
+	}
+	path := filepath.Join(deckDir, "a.md")
+	content := "---\ntags: [go]\nlast_reviewed: 2025-03-26T09:00:00Z\nreview_interval: 5\nstate: review\nlapses: 2\n---\n# Question\nQ\n# Answer\nA\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write card: %v", err)
+	}
+
+	store, err := NewStoreFromDirWithClock(tempDir, clock.Fixed(now))
+	if err != nil {
+		t.Fatalf("NewStoreFromDirWithClock error: %v", err)
+	}
+	store.Config.LeechThreshold = 3
+	studied := now.AddDate(0, 0, -3)
+	store.Decks[0].LastStudied = studied
+	fileTags := func() string {
+		parsed, err := ParseMarkdownFile(path)
+		if err != nil {
+			t.Fatalf("ParseMarkdownFile error: %v", err)
+		}
+		return strings.Join(parsed.FrontMatter.Tags, ",")
+	}
+
+	// The lapse reaching the threshold tags the file too
+	card, _ := store.GetCard(path)
+	if err := store.SaveCardReview(card, 1); err != nil {
+		t.Fatalf("SaveCardReview error: %v", err)
+	}
+	if tags := fileTags(); tags != "go,leech" {
+		t.Fatalf("Expected the leech tag in the file, got %q", tags)
+	}
+
+	// Undoing it removes the tag from the file and the deck was not studied
+	if err := store.UndoCardReview(card); err != nil {
+		t.Fatalf("UndoCardReview error: %v", err)
+	}
+	if tags := fileTags(); tags != "go" {
+		t.Errorf("Expected the leech tag removed from the file, got %q", tags)
+	}
+	if deck, _ := store.GetDeck(deckDir); !deck.LastStudied.Equal(studied) {
+		t.Errorf("Expected the deck last studied %v, got %v", studied, deck.LastStudied)
+	}
+}
@@ -0,0 +1,95 @@
+// File: internal/data/load_report.go
+
+package data
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// LoadError describes a problem with a file of the collection, such as a
+// card whose front matter is not valid YAML
+type LoadError struct {
+	Path string // File or directory with the problem
+	Line int    // 1-based line of the problem, 0 when it is not about a line
+	Err  error  // What is wrong
+}
+
+// Error formats the problem as path:line: reason
+func (e *LoadError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d: %v", e.Path, e.Line, e.Err)
+	}
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
+
+// LoadReport collects the problems found while loading a collection. Files
+// with problems are skipped, or loaded with default values, instead of
+// stopping the whole collection from loading.
+type LoadReport struct {
+	Errors []*LoadError
+}
+
+// Add records a problem with the file at path. Errors that already are a
+// LoadError keep their own path and line. A nil report ignores problems.
+func (r *LoadReport) Add(path string, err error) {
+	if r == nil || err == nil {
+		return
+	}
+
+	var loadErr *LoadError
+	if !errors.As(err, &loadErr) {
+		loadErr = &LoadError{Path: path, Err: err}
+	}
+	r.Errors = append(r.Errors, loadErr)
+}
+
+// Remove forgets the problems recorded for the file at path, as when the
+// file is loaded again
+func (r *LoadReport) Remove(path string) {
+	if r == nil {
+		return
+	}
+
+	var kept []*LoadError
+	for _, loadErr := range r.Errors {
+		if loadErr.Path != path {
+			kept = append(kept, loadErr)
+		}
+	}
+	r.Errors = kept
+}
+
+// HasErrors reports whether any problem was found
+func (r *LoadReport) HasErrors() bool {
+	return r != nil && len(r.Errors) > 0
+}
+
+// yamlLinePattern matches the line number in the errors of the YAML parser
+var yamlLinePattern = regexp.MustCompile(`line (\d+): `)
+
+// yamlError turns an error of the YAML parser into a LoadError, moving the
+// line it mentions into Line. firstLine is the line of the file the YAML
+// starts on.
+func yamlError(path string, firstLine int, context string, err error) *LoadError {
+	message := strings.TrimPrefix(err.Error(), "yaml: ")
+	message = strings.TrimPrefix(message, "unmarshal errors:\n  ")
+	message, _, _ = strings.Cut(message, "\n") // Only the first of several errors
+
+	line := 0
+	if match := yamlLinePattern.FindStringSubmatchIndex(message); match != nil {
+		n, _ := strconv.Atoi(message[match[2]:match[3]])
+		line = firstLine + n - 1
+		message = message[:match[0]] + message[match[1]:]
+	}
+
+	return &LoadError{Path: path, Line: line, Err: fmt.Errorf("%s: %s", context, message)}
+}
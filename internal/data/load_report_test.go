@@ -0,0 +1,95 @@
+// File: internal/data/load_report_test.go
+
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewStoreFromDirReportsBadFiles(t *testing.T) {
+	rootDir := t.TempDir()
+	deckDir := filepath.Join(rootDir, "deck")
+
+	files := map[string]string{
+		"good.md":            "---\ntags: []\n---\n# Question\nQ\n# Answer\nA\n",
+		"bad_yaml.md":        "---\ntags: []\ntitle: a: b\n---\n# Question\nQ\n",
+		"wrong_type.md":      "---\ntags: []\ncreated: 2025-03-22\nreview_interval: often\n---\n# Question\nQ\n",
+		"no_front_matter.md": "# Question\nQ\n",
+		"unclosed.md":        "---\ntags: []\n# Question\nQ\n",
+		"no_question.md":     "---\ntags: []\n---\nJust some notes\n",
+		HistoryFileName:      "{\"card\":\"good.md\",\"rating\":3}\nnot json\n",
+	}
+	if err := os.MkdirAll(deckDir, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(deckDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	configPath := filepath.Join(rootDir, ConfigFileName)
+	if err := os.WriteFile(configPath, []byte("scheduler: sm2\nday_start_hour: 25\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	store, err := NewStoreFromDir(rootDir)
+	if err != nil {
+		t.Fatalf("NewStoreFromDir error: %v", err)
+	}
+
+	// The good card is loaded even though the others are broken, and so
+	// is a file without a question, as before
+	if len(store.Decks) != 1 || len(store.Decks[0].Cards) != 2 {
+		t.Fatalf("Expected one deck with the good card and the notes, got %+v", store.Decks)
+	}
+	if len(store.History) != 1 {
+		t.Errorf("Expected the valid history line to be kept, got %d events", len(store.History))
+	}
+
+	expected := map[string]int{
+		filepath.Join(deckDir, "bad_yaml.md"):        3,
+		filepath.Join(deckDir, "wrong_type.md"):      4,
+		filepath.Join(deckDir, "no_front_matter.md"): 1,
+		filepath.Join(deckDir, "unclosed.md"):        1,
+		filepath.Join(deckDir, HistoryFileName):      2,
+		configPath:                                   0,
+	}
+	if len(store.Report.Errors) != len(expected) {
+		t.Errorf("Expected %d problems, got %d: %v", len(expected), len(store.Report.Errors), store.Report.Errors)
+	}
+	for _, problem := range store.Report.Errors {
+		line, ok := expected[problem.Path]
+		if !ok {
+			t.Errorf("Unexpected problem: %v", problem)
+			continue
+		}
+		if problem.Line != line {
+			t.Errorf("Expected %s to be reported at line %d, got %d (%v)", problem.Path, line, problem.Line, problem)
+		}
+		if problem.Err == nil || strings.Contains(problem.Err.Error(), "line ") {
+			t.Errorf("Expected the reason without the line number, got %v", problem.Err)
+		}
+	}
+}
+
+func TestLoadErrorString(t *testing.T) {
+	withLine := &LoadError{Path: "deck/card.md", Line: 3, Err: os.ErrInvalid}
+	if got := withLine.Error(); got != "deck/card.md:3: invalid argument" {
+		t.Errorf("Expected path:line: reason, got %q", got)
+	}
+
+	withoutLine := &LoadError{Path: "deck/card.md", Err: os.ErrInvalid}
+	if got := withoutLine.Error(); got != "deck/card.md: invalid argument" {
+		t.Errorf("Expected path: reason, got %q", got)
+	}
+
+	// A nil report ignores problems
+	var report *LoadReport
+	report.Add("card.md", os.ErrInvalid)
+	if report.HasErrors() {
+		t.Error("Expected a nil report to have no errors")
+	}
+}
@@ -19,9 +19,18 @@ import (
 type FrontMatter struct {
 	Tags           []string  `yaml:"tags"`
 	Created        time.Time `yaml:"created"`
-	LastReviewed   time.Time `yaml:"last_reviewed"`
+	LastReviewed   time.Time `yaml:"last_reviewed,omitempty"`
+	NextReview     time.Time `yaml:"next_review,omitempty"`
+	LastRating     int       `yaml:"last_rating,omitempty"` // Rating (1-5) of the last review
 	ReviewInterval int       `yaml:"review_interval"`
 	Difficulty     float64   `yaml:"difficulty"`
+	Stability      float64   `yaml:"stability,omitempty"`       // FSRS memory stability
+	FSRSDifficulty float64   `yaml:"fsrs_difficulty,omitempty"` // FSRS difficulty
+	State          string    `yaml:"state,omitempty"`           // "learning", "review" or "relearning"
+	Step           int       `yaml:"step,omitempty"`            // Current learning or relearning step
+	Lapses         int       `yaml:"lapses,omitempty"`          // Times the card was forgotten after it was learned
+	Suspended      bool      `yaml:"suspended,omitempty"`       // Left out of study sessions
+	BuriedUntil    time.Time `yaml:"buried_until,omitempty"`    // Left out of study sessions until then
 }
 
 // MarkdownCard represents a card in markdown format
@@ -32,11 +41,12 @@ type MarkdownCard struct {
 	Answer      string
 }
 
-// ParseMarkdownFile parses a markdown file into a MarkdownCard
+// ParseMarkdownFile parses a markdown file into a MarkdownCard. Problems
+// with the content of the file are returned as a *LoadError.
 func ParseMarkdownFile(path string) (*MarkdownCard, error) {
 	file, err := os.Open(path)
 	if err != nil {
-		return nil, fmt.Errorf("error opening file: %w", err)
+		return nil, &LoadError{Path: path, Err: fmt.Errorf("error opening file: %w", err)}
 	}
 	defer file.Close() //nolint:errcheck
 
@@ -48,21 +58,31 @@ func ParseMarkdownFile(path string) (*MarkdownCard, error) {
 
 	// Parse frontmatter
 	if !scanner.Scan() || scanner.Text() != "---" {
-		return nil, fmt.Errorf("missing frontmatter start")
+		return nil, &LoadError{Path: path, Line: 1, Err: fmt.Errorf("missing frontmatter start")}
 	}
 
 	var frontmatterLines []string
+	closed := false
 	for scanner.Scan() {
 		line := scanner.Text()
 		if line == "---" {
+			closed = true
 			break
 		}
 		frontmatterLines = append(frontmatterLines, line)
 	}
+	if !closed && scanner.Err() == nil {
+		return nil, &LoadError{Path: path, Line: 1, Err: fmt.Errorf("missing frontmatter end")}
+	}
 
 	frontmatter := strings.Join(frontmatterLines, "\n")
 	if err := yaml.Unmarshal([]byte(frontmatter), &card.FrontMatter); err != nil {
-		return nil, fmt.Errorf("error parsing frontmatter: %w", err)
+		return nil, yamlError(path, 2, "error parsing frontmatter", err)
+	}
+	if card.FrontMatter.State != "" {
+		if _, err := model.ParseCardState(card.FrontMatter.State); err != nil {
+			return nil, &LoadError{Path: path, Err: fmt.Errorf("error parsing frontmatter: %w", err)}
+		}
 	}
 
 	// Parse question and answer
@@ -89,7 +109,7 @@ func ParseMarkdownFile(path string) (*MarkdownCard, error) {
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error scanning file: %w", err)
+		return nil, &LoadError{Path: path, Err: fmt.Errorf("error scanning file: %w", err)}
 	}
 
 	card.Question = strings.TrimSpace(strings.Join(questionLines, "\n"))
@@ -100,16 +120,35 @@ func ParseMarkdownFile(path string) (*MarkdownCard, error) {
 
 // ToModelCard converts a MarkdownCard to a model.Card
 func (mc *MarkdownCard) ToModelCard(deckID string) model.Card {
-	// Set sensible defaults
-	now := time.Now()
+	return mc.ToModelCardAt(deckID, time.Now())
+}
+
+// ToModelCardAt converts a MarkdownCard to a model.Card, treating a card
+// that was never reviewed as due at the given time
+func (mc *MarkdownCard) ToModelCardAt(deckID string, now time.Time) model.Card {
 	lastReviewed := mc.FrontMatter.LastReviewed
-	if lastReviewed.IsZero() {
-		lastReviewed = now
+	interval := mc.FrontMatter.ReviewInterval
+
+	// Prefer the stored next review. Cards written before it was stored
+	// derive it from the interval, and cards never reviewed are due now.
+	nextReview := mc.FrontMatter.NextReview
+	if nextReview.IsZero() {
+		if lastReviewed.IsZero() {
+			nextReview = now
+		} else {
+			nextReview = lastReviewed.AddDate(0, 0, interval)
+		}
 	}
 
-	// Calculate next review based on interval
-	interval := mc.FrontMatter.ReviewInterval
-	nextReview := lastReviewed.AddDate(0, 0, interval)
+	// Cards written before states were stored are new until reviewed and
+	// then reviewed at intervals of days
+	state, err := model.ParseCardState(mc.FrontMatter.State)
+	if err != nil || mc.FrontMatter.State == "" {
+		state = model.StateNew
+		if !lastReviewed.IsZero() {
+			state = model.StateReview
+		}
+	}
 
 	// Default ease value if not specified
 	ease := mc.FrontMatter.Difficulty
@@ -126,7 +165,16 @@ func (mc *MarkdownCard) ToModelCard(deckID string) model.Card {
 		NextReview:   nextReview,
 		Ease:         ease,
 		Interval:     interval,
-		Rating:       0, // Default to 0 for new cards
+		Rating:       mc.FrontMatter.LastRating, // 0 for cards never reviewed
+		Stability:    mc.FrontMatter.Stability,
+		Difficulty:   mc.FrontMatter.FSRSDifficulty,
+		State:        state,
+		Step:         mc.FrontMatter.Step,
+		Lapses:       mc.FrontMatter.Lapses,
+		Tags:         mc.FrontMatter.Tags,
+		Created:      mc.FrontMatter.Created,
+		Suspended:    mc.FrontMatter.Suspended,
+		BuriedUntil:  mc.FrontMatter.BuriedUntil,
 	}
 }
 
@@ -157,8 +205,15 @@ func ScanDirForMarkdown(dirPath string) ([]string, error) {
 	return mdFiles, nil
 }
 
-// ImportMarkdownToDeck imports markdown files into an existing deck
-func ImportMarkdownToDeck(dirPath string, deck *model.Deck) error {
+// ImportMarkdownToDeck imports markdown files into an existing deck. Files
+// that cannot be parsed are skipped and added to the report, which may be nil.
+func ImportMarkdownToDeck(dirPath string, deck *model.Deck, report *LoadReport) error {
+	return importMarkdownToDeck(dirPath, deck, time.Now(), report)
+}
+
+// importMarkdownToDeck imports markdown files into a deck, with new cards
+// due at the given time
+func importMarkdownToDeck(dirPath string, deck *model.Deck, now time.Time, report *LoadReport) error {
 	mdFiles, err := ScanDirForMarkdown(dirPath)
 	if err != nil {
 		return err
@@ -167,18 +222,28 @@ func ImportMarkdownToDeck(dirPath string, deck *model.Deck) error {
 	for _, path := range mdFiles {
 		card, err := ParseMarkdownFile(path)
 		if err != nil {
-			return fmt.Errorf("error parsing %s: %w", path, err)
+			// Skip the card but keep loading the rest of the deck
+			report.Add(path, err)
+			continue
 		}
 
-		modelCard := card.ToModelCard(deck.ID)
+		modelCard := card.ToModelCardAt(deck.ID, now)
 		deck.Cards = append(deck.Cards, modelCard)
 	}
 
 	return nil
 }
 
-// CreateDeckFromDir creates a new deck from a directory of markdown files
-func CreateDeckFromDir(dirPath string) (*model.Deck, error) {
+// CreateDeckFromDir creates a new deck from a directory of markdown files.
+// Files that cannot be parsed are skipped and added to the report, which
+// may be nil.
+func CreateDeckFromDir(dirPath string, report *LoadReport) (*model.Deck, error) {
+	return createDeckFromDir(dirPath, time.Now(), report)
+}
+
+// createDeckFromDir creates a new deck from a directory of markdown files
+// as if it was loaded at the given time
+func createDeckFromDir(dirPath string, now time.Time, report *LoadReport) (*model.Deck, error) {
 	// Create a new deck
 	deckInfo, err := os.Stat(dirPath)
 	if err != nil {
@@ -192,13 +257,13 @@ func CreateDeckFromDir(dirPath string) (*model.Deck, error) {
 	deck := &model.Deck{
 		ID:          dirPath,
 		Name:        filepath.Base(dirPath),
-		CreatedAt:   time.Now(),
-		LastStudied: time.Now(),
+		CreatedAt:   now,
+		LastStudied: now,
 		Cards:       []model.Card{},
 	}
 
 	// Import markdown files
-	if err := ImportMarkdownToDeck(dirPath, deck); err != nil {
+	if err := importMarkdownToDeck(dirPath, deck, now, report); err != nil {
 		return nil, err
 	}
 
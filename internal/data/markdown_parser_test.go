@@ -141,6 +141,74 @@ func TestToModelCard(t *testing.T) {
 	}
 }
 
+func TestToModelCardSchedule(t *testing.T) {
+	now := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	// A stored next review and rating are used as they are
+	lastReviewed := time.Date(2025, 3, 23, 21, 15, 0, 0, time.UTC)
+	nextReview := time.Date(2025, 3, 27, 8, 0, 0, 0, time.UTC)
+	mc := &MarkdownCard{
+		FrontMatter: FrontMatter{
+			LastReviewed:   lastReviewed,
+			NextReview:     nextReview,
+			LastRating:     5,
+			ReviewInterval: 3,
+		},
+	}
+
+	card := mc.ToModelCardAt("deck", now)
+	if !card.NextReview.Equal(nextReview) {
+		t.Errorf("Expected stored next review %v, got %v", nextReview, card.NextReview)
+	}
+	if card.Rating != 5 {
+		t.Errorf("Expected rating 5, got %d", card.Rating)
+	}
+
+	// A card that was never reviewed keeps a zero last review and is due now
+	card = (&MarkdownCard{}).ToModelCardAt("deck", now)
+	if !card.LastReviewed.IsZero() {
+		t.Errorf("Expected a zero last review for a new card, got %v", card.LastReviewed)
+	}
+	if !card.NextReview.Equal(now) {
+		t.Errorf("Expected a new card to be due at %v, got %v", now, card.NextReview)
+	}
+}
+
+func TestToModelCardState(t *testing.T) {
+	now := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	lastReviewed := now.Add(-5 * time.Minute)
+
+	testCases := []struct {
+		name         string
+		frontMatter  FrontMatter
+		expected     model.CardState
+		expectedStep int
+	}{
+		{"never reviewed", FrontMatter{}, model.StateNew, 0},
+		{"reviewed without a state", FrontMatter{LastReviewed: lastReviewed}, model.StateReview, 0},
+		{"learning", FrontMatter{LastReviewed: lastReviewed, State: "learning", Step: 1}, model.StateLearning, 1},
+		{"relearning", FrontMatter{LastReviewed: lastReviewed, State: "relearning"}, model.StateRelearning, 0},
+	}
+
+	for _, tc := range testCases {
+		card := (&MarkdownCard{FrontMatter: tc.frontMatter}).ToModelCardAt("deck", now)
+		if card.State != tc.expected || card.Step != tc.expectedStep {
+			t.Errorf("%s: expected state %v at step %d, got %v at step %d",
+				tc.name, tc.expected, tc.expectedStep, card.State, card.Step)
+		}
+	}
+
+	// A state GoCard does not know makes the file fail to load
+	path := filepath.Join(t.TempDir(), "card.md")
+	content := "---\nstate: forgotten\n---\n# Question\nQ\n# Answer\nA\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write card: %v", err)
+	}
+	if _, err := ParseMarkdownFile(path); err == nil {
+		t.Errorf("Expected an error for an unknown state")
+	}
+}
+
 func TestScanDirForMarkdown(t *testing.T) {
 	// Create a temporary directory
 	tempDir, err := os.MkdirTemp("", "markdown-scan")
@@ -237,7 +305,7 @@ Test answer ` + string(rune('0'+i)) + `.
 	}
 
 	// Test importing
-	if err := ImportMarkdownToDeck(tempDir, deck); err != nil {
+	if err := ImportMarkdownToDeck(tempDir, deck, nil); err != nil {
 		t.Fatalf("ImportMarkdownToDeck error: %v", err)
 	}
 
@@ -292,7 +360,7 @@ Test answer ` + string(rune('0'+i)) + `.
 	}
 
 	// Test creating deck
-	deck, err := CreateDeckFromDir(tempDir)
+	deck, err := CreateDeckFromDir(tempDir, nil)
 	if err != nil {
 		t.Fatalf("CreateDeckFromDir error: %v", err)
 	}
@@ -313,7 +381,7 @@ Test answer ` + string(rune('0'+i)) + `.
 
 	// Check non-directory
 	nonDir := filepath.Join(tempDir, "test1.md")
-	_, err = CreateDeckFromDir(nonDir)
+	_, err = CreateDeckFromDir(nonDir, nil)
 	if err == nil {
 		t.Error("Expected error when creating deck from non-directory, got nil")
 	}
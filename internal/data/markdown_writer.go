@@ -3,6 +3,7 @@
 package data
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -16,18 +17,36 @@ import (
 
 // CardToMarkdown converts a model.Card to a MarkdownCard
 func CardToMarkdown(card model.Card) *MarkdownCard {
-	// Extract tags (if stored in the card)
-	tags := []string{}
+	// Cards without tags are written with an empty list
+	tags := card.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	// Cards without a creation time are created now
+	created := card.Created
+	if created.IsZero() {
+		created = time.Now()
+	}
 
 	// Create MarkdownCard
 	mc := &MarkdownCard{
 		Path: card.ID,
 		FrontMatter: FrontMatter{
 			Tags:           tags,
-			Created:        time.Now(), // Default to now if not available
+			Created:        created,
 			LastReviewed:   card.LastReviewed,
+			NextReview:     card.NextReview,
+			LastRating:     card.Rating,
 			ReviewInterval: card.Interval,
 			Difficulty:     card.Ease,
+			Stability:      card.Stability,
+			FSRSDifficulty: card.Difficulty,
+			State:          frontMatterState(card),
+			Step:           card.Step,
+			Lapses:         card.Lapses,
+			Suspended:      card.Suspended,
+			BuriedUntil:    card.BuriedUntil,
 		},
 		Question: card.Question,
 		Answer:   card.Answer,
@@ -87,19 +106,21 @@ func WriteMarkdownCard(mc *MarkdownCard, path string) error {
 	}
 
 	// Construct file content
-	content := fmt.Sprintf("---\n%s---\n\n# Question\n\n%s\n\n## Answer\n\n%s\n",
-		string(frontmatterBytes),
-		mc.Question,
-		mc.Answer)
+	content := joinFrontMatter(string(frontmatterBytes), formatCardBody(mc.Question, mc.Answer))
 
 	// Write to file
-	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+	if err := writeFileAtomic(path, []byte(content), 0644); err != nil {
 		return fmt.Errorf("error writing file: %w", err)
 	}
 
 	return nil
 }
 
+// formatCardBody formats the question and answer sections of a card file
+func formatCardBody(question, answer string) string {
+	return fmt.Sprintf("\n# Question\n\n%s\n\n## Answer\n\n%s\n", question, answer)
+}
+
 // WriteCard writes a model.Card to a markdown file
 func WriteCard(card model.Card, path string) error {
 	mc := CardToMarkdown(card)
@@ -156,7 +177,10 @@ func WriteNewDeck(deck *model.Deck) error {
 	return WriteDeckToMarkdown(deck, dirPath)
 }
 
-// UpdateCardFile updates an existing markdown file with modified card data
+// UpdateCardFile updates an existing markdown file with modified card data.
+// Only the SRS fields of the front matter are rewritten, so keys GoCard does
+// not know about and comments are kept. The body is rewritten only when the
+// question or answer changed, and the file is not written when nothing did.
 func UpdateCardFile(card model.Card) error {
 	// Check if file exists
 	_, err := os.Stat(card.ID)
@@ -168,21 +192,72 @@ func UpdateCardFile(card model.Card) error {
 		return fmt.Errorf("error checking file: %w", err)
 	}
 
-	// Read existing card to preserve metadata
+	// Read existing card to compare its content
 	existingCard, err := ParseMarkdownFile(card.ID)
 	if err != nil {
 		return fmt.Errorf("error reading existing card: %w", err)
 	}
 
-	// Update with new data while preserving tags and created date
-	mc := CardToMarkdown(card)
-	mc.FrontMatter.Tags = existingCard.FrontMatter.Tags
+	content, err := os.ReadFile(card.ID)
+	if err != nil {
+		return fmt.Errorf("error reading existing card: %w", err)
+	}
 
-	// Keep original creation date if it exists
-	if !existingCard.FrontMatter.Created.IsZero() {
-		mc.FrontMatter.Created = existingCard.FrontMatter.Created
+	frontMatter, body, ok := splitFrontMatter(string(content))
+	if !ok {
+		return fmt.Errorf("error reading existing card: missing frontmatter")
+	}
+
+	frontMatter, err = updateFrontMatterFields(frontMatter, card)
+	if err != nil {
+		return fmt.Errorf("error updating frontmatter: %w", err)
+	}
+
+	if existingCard.Question != card.Question || existingCard.Answer != card.Answer {
+		body = formatCardBody(card.Question, card.Answer)
 	}
 
 	// Write updated card
-	return WriteMarkdownCard(mc, card.ID)
+	updated := joinFrontMatter(frontMatter, body)
+	if updated == string(content) {
+		return nil
+	}
+	if err := writeFileAtomic(card.ID, []byte(updated), 0644); err != nil {
+		return fmt.Errorf("error writing file: %w", err)
+	}
+
+	return nil
+}
+
+// errNoFrontMatter is returned for card files without front matter to
+// write a schedule to
+var errNoFrontMatter = errors.New("missing frontmatter")
+
+// updateCardFrontMatter writes the SRS fields of a card to the front matter
+// of its markdown file. The body is left as it is on disk, so edits made
+// since the card was loaded are kept, and the file is not written when its
+// front matter does not change.
+func updateCardFrontMatter(card model.Card) error {
+	content, err := os.ReadFile(card.ID)
+	if err != nil {
+		return fmt.Errorf("error reading card file: %w", err)
+	}
+
+	frontMatter, body, ok := splitFrontMatter(string(content))
+	if !ok {
+		return errNoFrontMatter
+	}
+
+	updated, err := updateFrontMatterFields(frontMatter, card)
+	if err != nil {
+		return fmt.Errorf("error updating frontmatter: %w", err)
+	}
+	if updated == frontMatter {
+		return nil // Nothing to save
+	}
+
+	if err := writeFileAtomic(card.ID, []byte(joinFrontMatter(updated, body)), 0644); err != nil {
+		return fmt.Errorf("error writing file: %w", err)
+	}
+	return nil
 }
@@ -5,6 +5,7 @@ package data
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 
@@ -62,6 +63,8 @@ func TestWriteCard(t *testing.T) {
 		Ease:         2.5,
 		Interval:     3,
 		Rating:       4,
+		Tags:         []string{"go", "testing"},
+		Created:      time.Date(2025, 3, 22, 0, 0, 0, 0, time.UTC),
 	}
 
 	// Write card to file
@@ -97,6 +100,15 @@ func TestWriteCard(t *testing.T) {
 	if readCard.FrontMatter.Difficulty != card.Ease {
 		t.Errorf("Expected difficulty %f, got %f", card.Ease, readCard.FrontMatter.Difficulty)
 	}
+
+	// Tags and the creation date are carried back into the card
+	modelCard := readCard.ToModelCard(card.DeckID)
+	if !reflect.DeepEqual(modelCard.Tags, card.Tags) {
+		t.Errorf("Expected tags %v, got %v", card.Tags, modelCard.Tags)
+	}
+	if !modelCard.Created.Equal(card.Created) {
+		t.Errorf("Expected created %v, got %v", card.Created, modelCard.Created)
+	}
 }
 
 func TestWriteDeckToMarkdown(t *testing.T) {
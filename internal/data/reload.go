@@ -0,0 +1,197 @@
+// File: internal/data/reload.go
+
+package data
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+// ReloadFiles brings the cards of the given markdown files up to date with
+// the files on disk. Changed files are parsed again, new files in a deck
+// directory are added to that deck and cards whose file is gone are
+// removed. Files in a new directory of the collection load it as a new deck,
+// or as a sub-deck of the deck it is in. A card whose file can no longer be
+// parsed keeps its last loaded version, with the problem added to the
+// report.
+func (s *Store) ReloadFiles(paths []string) {
+	now := s.Now()
+
+	for _, path := range paths {
+		s.Report.Remove(path)
+
+		deckIndex := s.deckIndex(filepath.Dir(path))
+		if deckIndex < 0 {
+			deckIndex = s.loadNewDeck(filepath.Dir(path), now)
+		}
+		if deckIndex < 0 {
+			continue // Not in a deck
+		}
+
+		parsed, err := ParseMarkdownFile(path)
+		switch {
+		case err == nil:
+			s.reloadCard(deckIndex, parsed.ToModelCardAt(s.Decks[deckIndex].ID, now))
+		case errors.Is(err, fs.ErrNotExist):
+			s.removeCard(deckIndex, path)
+		default:
+			s.Report.Add(path, err)
+		}
+	}
+}
+
+// loadNewDeck loads a directory created in the collection after it was
+// loaded, with its sub-decks, and returns the index of its deck. Missing
+// parent directories are loaded as decks too. It returns -1 for directories
+// outside the collection, hidden ones, and those of a collection loaded as a
+// single deck, which has no sub-decks.
+func (s *Store) loadNewDeck(dirPath string, now time.Time) int {
+	if s.dir == "" {
+		return -1
+	}
+	rel, err := filepath.Rel(s.dir, dirPath)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return -1
+	}
+	for _, name := range strings.Split(rel, string(filepath.Separator)) {
+		if strings.HasPrefix(name, ".") {
+			return -1
+		}
+	}
+
+	// Load from the topmost directory that is not a deck yet
+	top := dirPath
+	for filepath.Dir(top) != s.dir && s.deckIndex(filepath.Dir(top)) < 0 {
+		top = filepath.Dir(top)
+	}
+	if info, err := os.Stat(top); err != nil || !info.IsDir() {
+		return -1
+	}
+
+	parentID := filepath.Dir(top)
+	if parentID == s.dir {
+		if s.deckIndex(s.dir) >= 0 {
+			return -1 // The collection is a single deck
+		}
+		parentID = ""
+	}
+	s.loadDeckTree(top, parentID, s.ConfigForDeck(parentID), now)
+
+	return s.deckIndex(dirPath)
+}
+
+// ReloadCard parses the file of a card again after it was edited, as in the
+// user's editor. The card takes its question, answer and tags from the
+// file and keeps the schedule it has in the store, which is written back if
+// the file has another one. A card whose file is gone is removed.
+func (s *Store) ReloadCard(cardID string) error {
+	current, found := s.GetCard(cardID)
+	if !found {
+		return fmt.Errorf("card not found: %s", cardID)
+	}
+	deckIndex := s.deckIndex(current.DeckID)
+
+	s.Report.Remove(cardID)
+	parsed, err := ParseMarkdownFile(cardID)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		s.removeCard(deckIndex, cardID)
+		return nil
+	case err != nil:
+		s.Report.Add(cardID, err)
+		return fmt.Errorf("error reloading card: %w", err)
+	}
+
+	fromFile := parsed.ToModelCardAt(current.DeckID, s.Now())
+	card := withSchedule(fromFile, current)
+	s.UpdateCard(card)
+
+	if !sameSchedule(card, fromFile) {
+		return s.saveCardFile(card)
+	}
+	return nil
+}
+
+// sameSchedule reports whether two versions of a card have the same
+// schedule as far as their file is concerned: the fields written to the
+// front matter, with review times to the second
+func sameSchedule(a, b model.Card) bool {
+	if formatTimestamp(a.LastReviewed) != formatTimestamp(b.LastReviewed) ||
+		a.Interval != b.Interval || formatEase(a.Ease) != formatEase(b.Ease) || a.Lapses != b.Lapses {
+		return false
+	}
+	if a.LastReviewed.IsZero() {
+		return true // Cards never reviewed have no more schedule in their file
+	}
+	return formatTimestamp(a.NextReview) == formatTimestamp(b.NextReview) &&
+		a.Rating == b.Rating && frontMatterState(a) == frontMatterState(b) && a.Step == b.Step &&
+		fmt.Sprintf("%.4f %.4f", a.Stability, a.Difficulty) == fmt.Sprintf("%.4f %.4f", b.Stability, b.Difficulty)
+}
+
+// reloadCard replaces a card of a deck with the version just read from its
+// file, or adds it to the deck if it is new. When the file has an older
+// schedule than the card, as when an editor saves a copy opened before the
+// card was last reviewed, the card keeps its schedule and it is written
+// back to the file. Review times are compared to the second, as files store
+// them, so the file of a card just saved is not taken for an older one.
+func (s *Store) reloadCard(deckIndex int, card model.Card) {
+	cards := s.Decks[deckIndex].Cards
+	for i, current := range cards {
+		if current.ID != card.ID {
+			continue
+		}
+
+		if card.LastReviewed.Before(current.LastReviewed.Truncate(time.Second)) {
+			card = withSchedule(card, current)
+			s.Report.Add(card.ID, s.saveCardFile(card))
+		}
+		cards[i] = card
+		return
+	}
+
+	s.Decks[deckIndex].Cards = append(cards, card)
+}
+
+// withSchedule returns a card with the schedule of another version of it
+func withSchedule(card, schedule model.Card) model.Card {
+	card.LastReviewed = schedule.LastReviewed
+	card.NextReview = schedule.NextReview
+	card.Ease = schedule.Ease
+	card.Interval = schedule.Interval
+	card.Rating = schedule.Rating
+	card.Stability = schedule.Stability
+	card.Difficulty = schedule.Difficulty
+	card.State = schedule.State
+	card.Step = schedule.Step
+	card.Lapses = schedule.Lapses
+	return card
+}
+
+// removeCard removes a card from a deck
+func (s *Store) removeCard(deckIndex int, cardID string) {
+	cards := s.Decks[deckIndex].Cards
+	for i, card := range cards {
+		if card.ID == cardID {
+			s.Decks[deckIndex].Cards = append(cards[:i:i], cards[i+1:]...)
+			return
+		}
+	}
+}
+
+// deckIndex returns the index of a deck in the store, or -1 if there is no
+// such deck
+func (s *Store) deckIndex(deckID string) int {
+	for i, deck := range s.Decks {
+		if deck.ID == deckID {
+			return i
+		}
+	}
+	return -1
+}
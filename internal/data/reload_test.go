@@ -0,0 +1,308 @@
+// File: internal/data/reload_test.go
+
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DavidMiserak/GoCard/internal/clock"
+)
+
+func TestReloadFiles(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+
+	tempDir := t.TempDir()
+	deckDir := filepath.Join(tempDir, "go")
+	if err := os.Mkdir(deckDir, 0755); err != nil {
+		t.Fatalf("Failed to create deck dir: %v", err)
+	}
+	writeCard := func(name, question string) string {
+		path := filepath.Join(deckDir, name)
+		content := "---\ntags: [go]\n---\n# Question\n" + question + "\n# Answer\nA\n"
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write card: %v", err)
+		}
+		return path
+	}
+	cardA := writeCard("a.md", "Old question")
+	cardB := writeCard("b.md", "B")
+
+	store, err := NewStoreFromDirWithClock(tempDir, clock.Fixed(now))
+	if err != nil {
+		t.Fatalf("NewStoreFromDirWithClock error: %v", err)
+	}
+	if err := store.SaveCardReview(store.Decks[0].Cards[0], 4); err != nil {
+		t.Fatalf("SaveCardReview error: %v", err)
+	}
+	reviewed, _ := store.GetCard(cardA)
+
+	// Editing the question of a reviewed card keeps its schedule
+	content, _ := os.ReadFile(cardA)
+	if err := os.WriteFile(cardA, []byte(strings.Replace(string(content), "Old question", "New question", 1)), 0644); err != nil {
+		t.Fatalf("Failed to edit card: %v", err)
+	}
+	// A card is added and another removed
+	cardC := writeCard("c.md", "C")
+	if err := os.Remove(cardB); err != nil {
+		t.Fatalf("Failed to remove card: %v", err)
+	}
+
+	store.ReloadFiles([]string{cardA, cardB, cardC, filepath.Join(tempDir, "elsewhere", "d.md")})
+
+	card, found := store.GetCard(cardA)
+	if !found || card.Question != "New question" {
+		t.Fatalf("Expected the new question, got %+v", card)
+	}
+	if !card.LastReviewed.Equal(reviewed.LastReviewed) || !card.NextReview.Equal(reviewed.NextReview) || card.Interval != reviewed.Interval {
+		t.Errorf("Expected the schedule to be kept, got %+v", card)
+	}
+	if _, found := store.GetCard(cardB); found {
+		t.Error("Expected the removed card to be gone")
+	}
+	if card, found := store.GetCard(cardC); !found || card.DeckID != deckDir {
+		t.Errorf("Expected the new card in the deck, got %+v", card)
+	}
+
+	// An editor saving a copy opened before the review keeps the review
+	writeCard("a.md", "Stale question")
+	store.ReloadFiles([]string{cardA})
+	card, _ = store.GetCard(cardA)
+	if card.Question != "Stale question" || !card.LastReviewed.Equal(reviewed.LastReviewed) {
+		t.Errorf("Expected the edit with the latest schedule, got %+v", card)
+	}
+	if content, _ := os.ReadFile(cardA); !strings.Contains(string(content), "last_reviewed:") {
+		t.Errorf("Expected the schedule to be written back, got:\n%s", content)
+	}
+
+	// A card that no longer parses keeps its last version until fixed
+	if err := os.WriteFile(cardC, []byte("no front matter"), 0644); err != nil {
+		t.Fatalf("Failed to break card: %v", err)
+	}
+	store.ReloadFiles([]string{cardC})
+	if _, found := store.GetCard(cardC); !found || len(store.Report.Errors) != 1 {
+		t.Errorf("Expected the card kept with one problem reported, got %d problems", len(store.Report.Errors))
+	}
+	writeCard("c.md", "C")
+	store.ReloadFiles([]string{cardC})
+	if store.Report.HasErrors() {
+		t.Errorf("Expected the problem to be cleared, got %v", store.Report.Errors)
+	}
+}
+
+func TestReloadFilesNewDecks(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	content := "---\ntags: [go]\n---\n# Question\nQ\n# Answer\nA\n"
+
+	tempDir := t.TempDir()
+	goDir := filepath.Join(tempDir, "go")
+	if err := os.Mkdir(goDir, 0755); err != nil {
+		t.Fatalf("Failed to create deck dir: %v", err)
+	}
+	store, err := NewStoreFromDirWithClock(tempDir, clock.Fixed(now))
+	if err != nil {
+		t.Fatalf("NewStoreFromDirWithClock error: %v", err)
+	}
+
+	// A deck, a sub-deck in two new directories and a hidden directory are
+	// created while the collection is loaded
+	var paths []string
+	for _, dir := range []string{"rust", filepath.Join("go", "channels", "select"), ".git"} {
+		path := filepath.Join(tempDir, dir, "a.md")
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create deck dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write card: %v", err)
+		}
+		paths = append(paths, path)
+	}
+	store.ReloadFiles(paths)
+
+	rustDir := filepath.Join(tempDir, "rust")
+	channelsDir := filepath.Join(goDir, "channels")
+	selectDir := filepath.Join(channelsDir, "select")
+	for dir, parentID := range map[string]string{rustDir: "", channelsDir: goDir, selectDir: channelsDir} {
+		if deck, found := store.GetDeck(dir); !found || deck.ParentID != parentID {
+			t.Errorf("Expected a deck in %s below %q, got %+v", dir, parentID, deck)
+		}
+	}
+	if card, found := store.GetCard(paths[1]); !found || card.DeckID != selectDir {
+		t.Errorf("Expected the card in the new sub-deck, got %+v", card)
+	}
+	if deck, _ := store.GetDeckWithSubDecks(goDir); len(deck.Cards) != 1 {
+		t.Errorf("Expected the card of the sub-deck in the go deck, got %d cards", len(deck.Cards))
+	}
+	if len(store.Decks) != 4 {
+		t.Errorf("Expected 4 decks without the hidden directory, got %d", len(store.Decks))
+	}
+}
+
+func TestReloadFilesAfterReview(t *testing.T) {
+	// Files store review times to the second, the store keeps them exact
+	now := time.Date(2025, 3, 31, 9, 0, 0, 123456789, time.UTC)
+
+	tempDir := t.TempDir()
+	deckDir := filepath.Join(tempDir, "go")
+	if err := os.Mkdir(deckDir, 0755); err != nil {
+		t.Fatalf("Failed to create deck dir: %v", err)
+	}
+	path := filepath.Join(deckDir, "a.md")
+	if err := os.WriteFile(path, []byte("---\ntags: [go]\n---\n# Question\nQ\n# Answer\nA\n"), 0644); err != nil {
+		t.Fatalf("Failed to write card: %v", err)
+	}
+
+	store, err := NewStoreFromDirWithClock(tempDir, clock.Fixed(now))
+	if err != nil {
+		t.Fatalf("NewStoreFromDirWithClock error: %v", err)
+	}
+	card, _ := store.GetCard(path)
+	if err := store.SaveCardReview(card, 4); err != nil {
+		t.Fatalf("SaveCardReview error: %v", err)
+	}
+	saved, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat card: %v", err)
+	}
+
+	// Reloading the file just written, as the watcher does, leaves it alone
+	store.ReloadFiles([]string{path})
+	reloaded, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat card: %v", err)
+	}
+	if !os.SameFile(saved, reloaded) {
+		t.Error("Expected the card file not to be written again")
+	}
+	if card, _ := store.GetCard(path); card.LastReviewed.IsZero() {
+		t.Errorf("Expected the review to be kept, got %+v", card)
+	}
+}
+
+func TestReloadCardAfterReview(t *testing.T) {
+	// Files store review times to the second, the store keeps them exact
+	now := time.Date(2025, 3, 31, 9, 0, 0, 123456789, time.UTC)
+
+	tempDir := t.TempDir()
+	deckDir := filepath.Join(tempDir, "go")
+	if err := os.Mkdir(deckDir, 0755); err != nil {
+		t.Fatalf("Failed to create deck dir: %v", err)
+	}
+	path := filepath.Join(deckDir, "a.md")
+	if err := os.WriteFile(path, []byte("---\ntags: [go]\n---\n# Question\nTypo\n# Answer\nA\n"), 0644); err != nil {
+		t.Fatalf("Failed to write card: %v", err)
+	}
+
+	store, err := NewStoreFromDirWithClock(tempDir, clock.Fixed(now))
+	if err != nil {
+		t.Fatalf("NewStoreFromDirWithClock error: %v", err)
+	}
+	card, _ := store.GetCard(path)
+	if err := store.SaveCardReview(card, 4); err != nil {
+		t.Fatalf("SaveCardReview error: %v", err)
+	}
+
+	// Only the question is edited: the schedule needs no writing back
+	content, _ := os.ReadFile(path)
+	edited := strings.Replace(string(content), "Typo", "Fixed", 1)
+	if err := os.WriteFile(path, []byte(edited), 0644); err != nil {
+		t.Fatalf("Failed to edit card: %v", err)
+	}
+	saved, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat card: %v", err)
+	}
+
+	if err := store.ReloadCard(path); err != nil {
+		t.Fatalf("ReloadCard error: %v", err)
+	}
+	reloaded, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat card: %v", err)
+	}
+	if !os.SameFile(saved, reloaded) {
+		t.Error("Expected the card file not to be written again")
+	}
+	if card, _ := store.GetCard(path); card.Question != "Fixed" {
+		t.Errorf("Expected the edited question, got %q", card.Question)
+	}
+}
+
+func TestReloadCard(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+
+	tempDir := t.TempDir()
+	deckDir := filepath.Join(tempDir, "go")
+	if err := os.Mkdir(deckDir, 0755); err != nil {
+		t.Fatalf("Failed to create deck dir: %v", err)
+	}
+	path := filepath.Join(deckDir, "a.md")
+	if err := os.WriteFile(path, []byte("---\ntags: [go]\n---\n# Question\nTypo\n# Answer\nA\n"), 0644); err != nil {
+		t.Fatalf("Failed to write card: %v", err)
+	}
+
+	store, err := NewStoreFromDirWithClock(tempDir, clock.Fixed(now))
+	if err != nil {
+		t.Fatalf("NewStoreFromDirWithClock error: %v", err)
+	}
+	card, _ := store.GetCard(path)
+	if err := store.SaveCardReview(card, 4); err != nil {
+		t.Fatalf("SaveCardReview error: %v", err)
+	}
+	reviewed, _ := store.GetCard(path)
+
+	// The editor saves the fixed question, with the schedule changed too
+	content, _ := os.ReadFile(path)
+	edited := strings.Replace(string(content), "Typo", "Fixed", 1)
+	edited = strings.Replace(edited, "review_interval: "+strconv.Itoa(reviewed.Interval), "review_interval: 99", 1)
+	edited = strings.Replace(edited, "tags: [go]", "tags: [go, basics]", 1)
+	if err := os.WriteFile(path, []byte(edited), 0644); err != nil {
+		t.Fatalf("Failed to edit card: %v", err)
+	}
+
+	if err := store.ReloadCard(path); err != nil {
+		t.Fatalf("ReloadCard error: %v", err)
+	}
+	card, _ = store.GetCard(path)
+	if card.Question != "Fixed" || !card.HasTag("basics") {
+		t.Errorf("Expected the question and tags from the file, got %+v", card)
+	}
+	if card.Interval != reviewed.Interval || !card.NextReview.Equal(reviewed.NextReview) || card.State != reviewed.State {
+		t.Errorf("Expected the schedule to be kept, got %+v", card)
+	}
+	if content, _ := os.ReadFile(path); !strings.Contains(string(content), "review_interval: "+strconv.Itoa(reviewed.Interval)+"\n") {
+		t.Errorf("Expected the schedule written back, got:\n%s", content)
+	}
+
+	// A file that no longer parses keeps the card as it was
+	if err := os.WriteFile(path, []byte("---\ntags: [go\n---\n# Question\nBroken\n# Answer\nA\n"), 0644); err != nil {
+		t.Fatalf("Failed to edit card: %v", err)
+	}
+	if err := store.ReloadCard(path); err == nil {
+		t.Error("Expected an error reloading a broken card")
+	}
+	if card, _ := store.GetCard(path); card.Question != "Fixed" {
+		t.Errorf("Expected the last version of the card, got %q", card.Question)
+	}
+	if !store.Report.HasErrors() {
+		t.Error("Expected the broken file to be reported")
+	}
+
+	// A deleted file removes the card
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("Failed to remove card: %v", err)
+	}
+	if err := store.ReloadCard(path); err != nil {
+		t.Fatalf("ReloadCard error: %v", err)
+	}
+	if _, found := store.GetCard(path); found {
+		t.Error("Expected the card to be removed")
+	}
+	if store.Report.HasErrors() {
+		t.Errorf("Expected the problem with the removed file to be forgotten, got %v", store.Report.Errors)
+	}
+}
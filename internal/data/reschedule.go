@@ -0,0 +1,47 @@
+// File: internal/data/reschedule.go
+
+package data
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/DavidMiserak/GoCard/internal/clock"
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+// ResetCard forgets the schedule of a card, so it is studied as a new card
+// again. Its review history, tags and suspension are kept.
+func (s *Store) ResetCard(cardID string) error {
+	now := s.Now()
+	return s.changeCard(cardID, func(card *model.Card) {
+		card.LastReviewed = time.Time{}
+		card.NextReview = now
+		card.Ease = 2.5 // Default difficulty value
+		card.Interval = 0
+		card.Rating = 0
+		card.Stability = 0
+		card.Difficulty = 0
+		card.State = model.StateNew
+		card.Step = 0
+		card.Lapses = 0
+	})
+}
+
+// RescheduleCard makes a reviewed card due at the given time instead of
+// when its schedule has it, with its interval counted from its last review
+func (s *Store) RescheduleCard(cardID string, due time.Time) error {
+	card, found := s.GetCard(cardID)
+	if !found {
+		return fmt.Errorf("card not found: %s", cardID)
+	}
+	if isNewCard(card) {
+		return fmt.Errorf("card has not been reviewed yet: %s", cardID)
+	}
+
+	days := clock.DaysBetween(s.StartOfDay(card.LastReviewed), s.StartOfDay(due))
+	return s.changeCard(cardID, func(card *model.Card) {
+		card.NextReview = due
+		card.Interval = max(days, 1)
+	})
+}
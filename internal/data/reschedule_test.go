@@ -0,0 +1,86 @@
+// File: internal/data/reschedule_test.go
+
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DavidMiserak/GoCard/internal/clock"
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+func TestResetAndRescheduleCard(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+
+	tempDir := t.TempDir()
+	deckDir := filepath.Join(tempDir, "go")
+	if err := os.Mkdir(deckDir, 0755); err != nil {
+		t.Fatalf("Failed to create deck dir: %v", err)
+	}
+	path := filepath.Join(deckDir, "a.md")
+	content := "---\ntags: [go]\n---\n# Question\nQ\n# Answer\nA\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write card: %v", err)
+	}
+
+	store, err := NewStoreFromDirWithClock(tempDir, clock.Fixed(now))
+	if err != nil {
+		t.Fatalf("NewStoreFromDirWithClock error: %v", err)
+	}
+	fileContent := func() string {
+		content, _ := os.ReadFile(path)
+		return string(content)
+	}
+
+	// New cards have no schedule to change
+	if err := store.RescheduleCard(path, now.AddDate(0, 0, 3)); err == nil {
+		t.Error("Expected an error rescheduling a new card")
+	}
+
+	card, _ := store.GetCard(path)
+	if err := store.SaveCardReview(card, 4); err != nil {
+		t.Fatalf("SaveCardReview error: %v", err)
+	}
+	card, _ = store.GetCard(path)
+	card.Lapses = 2
+	store.UpdateCard(card)
+
+	// Rescheduling counts the interval from the last review
+	due := store.Today().AddDate(0, 0, 10)
+	if err := store.RescheduleCard(path, due); err != nil {
+		t.Fatalf("RescheduleCard error: %v", err)
+	}
+	card, _ = store.GetCard(path)
+	if !card.NextReview.Equal(due) || card.Interval != 10 {
+		t.Errorf("Expected the card due %v after 10 days, got %v after %d", due, card.NextReview, card.Interval)
+	}
+	if !strings.Contains(fileContent(), "review_interval: 10\n") || !strings.Contains(fileContent(), "next_review: 2025-04-10T00:00:00Z\n") {
+		t.Errorf("Expected the new schedule in the file, got:\n%s", fileContent())
+	}
+
+	// Resetting makes it a new card again, in the file too
+	if err := store.ResetCard(path); err != nil {
+		t.Fatalf("ResetCard error: %v", err)
+	}
+	card, _ = store.GetCard(path)
+	if !card.LastReviewed.IsZero() || card.State != model.StateNew || card.Interval != 0 || card.Ease != 2.5 || card.Lapses != 0 {
+		t.Errorf("Expected a new card, got %+v", card)
+	}
+	if !isNewCard(card) || !store.IsDue(card) {
+		t.Error("Expected the reset card to be studied as a new card")
+	}
+	if content := fileContent(); strings.Contains(content, "last_reviewed") || strings.Contains(content, "next_review") {
+		t.Errorf("Expected the schedule removed from the file, got:\n%s", content)
+	}
+	if len(store.GetCardHistory(path)) != 1 {
+		t.Error("Expected the review history to be kept")
+	}
+
+	if err := store.ResetCard(filepath.Join(deckDir, "missing.md")); err == nil {
+		t.Error("Expected an error resetting a missing card")
+	}
+}
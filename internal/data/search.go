@@ -0,0 +1,197 @@
+// File: internal/data/search.go
+
+package data
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+// Scores of a search term by where it is found in a card. Each term counts
+// once, where it scores highest.
+const (
+	scoreTag      = 8 // The term is one of the card's tags
+	scoreQuestion = 6 // The term is in the question
+	scoreTagPart  = 5 // The term is part of a tag
+	scorePath     = 4 // The term is in the file name or deck directory
+	scoreAnswer   = 2 // The term is in the answer
+	scoreFuzzy    = 1 // The letters of the term appear in order in the question or path
+
+	// Added when a term starts a word rather than being inside one
+	scoreWordStart = 1
+)
+
+// SearchResult is a card found by a search
+type SearchResult struct {
+	Card  model.Card
+	Score int // Higher for better matches
+}
+
+// SearchIndex finds cards by their question, answer, tags and file path.
+// Each word of a query must match a card, either as a piece of its text or
+// with its letters in order in the question or path, ignoring case.
+type SearchIndex struct {
+	entries []searchEntry
+
+	// The last query and its matches, narrowed down when the query is typed
+	// further instead of searching every card again
+	lastQuery   string
+	lastMatches []int
+}
+
+// searchEntry is a card with its text prepared for matching
+type searchEntry struct {
+	card     model.Card
+	question string
+	answer   string
+	tags     []string
+	path     string
+}
+
+// NewSearchIndex indexes the cards of every deck of the store. The index is
+// not updated when cards change: build a new one to search them.
+func NewSearchIndex(store *Store) *SearchIndex {
+	index := &SearchIndex{}
+	for _, deck := range store.Decks {
+		for _, card := range deck.Cards {
+			entry := searchEntry{
+				card:     card,
+				question: strings.ToLower(card.Question),
+				answer:   strings.ToLower(card.Answer),
+				path:     strings.ToLower(filepath.Join(filepath.Base(card.DeckID), filepath.Base(card.ID))),
+			}
+			for _, tag := range card.Tags {
+				entry.tags = append(entry.tags, strings.ToLower(tag))
+			}
+			index.entries = append(index.entries, entry)
+		}
+	}
+	return index
+}
+
+// Len returns the number of cards in the index
+func (idx *SearchIndex) Len() int {
+	return len(idx.entries)
+}
+
+// Search returns the cards matching every word of a query, best matches
+// first. An empty query matches nothing.
+func (idx *SearchIndex) Search(query string) []SearchResult {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		idx.lastQuery, idx.lastMatches = "", nil
+		return nil
+	}
+
+	// A card matching the query typed further also matched the query before
+	candidates := idx.lastMatches
+	if idx.lastQuery == "" || !strings.HasPrefix(query, idx.lastQuery) {
+		candidates = make([]int, len(idx.entries))
+		for i := range candidates {
+			candidates[i] = i
+		}
+	}
+
+	var matches []int
+	var results []SearchResult
+	for _, i := range candidates {
+		if score := idx.entries[i].score(terms); score > 0 {
+			matches = append(matches, i)
+			results = append(results, SearchResult{Card: idx.entries[i].card, Score: score})
+		}
+	}
+	idx.lastQuery, idx.lastMatches = query, matches
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results
+}
+
+// score returns how well a card matches the terms of a query, or 0 if a
+// term does not match it at all
+func (e searchEntry) score(terms []string) int {
+	total := 0
+	for _, term := range terms {
+		score := e.termScore(term)
+		if score == 0 {
+			return 0
+		}
+		total += score
+	}
+	return total
+}
+
+// termScore returns how well a card matches a single term
+func (e searchEntry) termScore(term string) int {
+	best := 0
+	better := func(score int) {
+		if score > best {
+			best = score
+		}
+	}
+
+	for _, tag := range e.tags {
+		if tag == term {
+			better(scoreTag)
+		} else if strings.Contains(tag, term) {
+			better(scoreTagPart)
+		}
+	}
+	better(textScore(e.question, term, scoreQuestion))
+	better(textScore(e.path, term, scorePath))
+	if best < scoreAnswer {
+		better(textScore(e.answer, term, scoreAnswer))
+	}
+	if best == 0 && (isSubsequence(e.question, term) || isSubsequence(e.path, term)) {
+		better(scoreFuzzy)
+	}
+
+	return best
+}
+
+// textScore returns the score of a term found in a text, more if it starts
+// a word there, or 0 if the text does not contain it
+func textScore(text, term string, score int) int {
+	at := strings.Index(text, term)
+	if at < 0 {
+		return 0
+	}
+	for ; at >= 0; at = nextIndex(text, term, at) {
+		if at == 0 || !isWordChar(text[at-1]) {
+			return score + scoreWordStart
+		}
+	}
+	return score
+}
+
+// nextIndex returns where a term is found in a text after a previous
+// match, or -1
+func nextIndex(text, term string, previous int) int {
+	at := strings.Index(text[previous+1:], term)
+	if at < 0 {
+		return -1
+	}
+	return previous + 1 + at
+}
+
+// isWordChar reports whether a byte is part of a word
+func isWordChar(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= '0' && b <= '9' || b == '_' || b >= 0x80
+}
+
+// isSubsequence reports whether the letters of a term appear in order in a
+// text
+func isSubsequence(text, term string) bool {
+	for _, r := range term {
+		at := strings.IndexRune(text, r)
+		if at < 0 {
+			return false
+		}
+		text = text[at+len(string(r)):]
+	}
+	return true
+}
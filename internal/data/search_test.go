@@ -0,0 +1,123 @@
+// File: internal/data/search_test.go
+
+package data
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+// newSearchStore returns a store with a few cards to search
+func newSearchStore() *Store {
+	return &Store{
+		Decks: []model.Deck{
+			{ID: "/cards/go", Name: "go", Cards: []model.Card{
+				{ID: "/cards/go/goroutines.md", DeckID: "/cards/go", Question: "What is a goroutine?", Answer: "A lightweight thread managed by the Go runtime.", Tags: []string{"go", "concurrency"}},
+				{ID: "/cards/go/channels.md", DeckID: "/cards/go", Question: "How do channels synchronize goroutines?", Answer: "Sends block until a receiver is ready.", Tags: []string{"go"}},
+				{ID: "/cards/go/slices.md", DeckID: "/cards/go", Question: "What does append return?", Answer: "The updated slice, possibly with a new backing array.", Tags: []string{"go", "basics"}},
+			}},
+			{ID: "/cards/rust", Name: "rust", Cards: []model.Card{
+				{ID: "/cards/rust/threads.md", DeckID: "/cards/rust", Question: "How are threads spawned?", Answer: "With std::thread::spawn.", Tags: []string{"rust", "Concurrency"}},
+			}},
+		},
+	}
+}
+
+// resultIDs returns the IDs of the cards found by a search in order
+func resultIDs(results []SearchResult) []string {
+	ids := make([]string, len(results))
+	for i, result := range results {
+		ids[i] = result.Card.ID
+	}
+	return ids
+}
+
+func TestSearch(t *testing.T) {
+	index := NewSearchIndex(newSearchStore())
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		// Questions rank above answers
+		{"goroutine", []string{"/cards/go/goroutines.md", "/cards/go/channels.md"}},
+		// Tags are matched regardless of case, exact tags first
+		{"concurrency", []string{"/cards/go/goroutines.md", "/cards/rust/threads.md"}},
+		// Every word must match
+		{"thread spawn", []string{"/cards/rust/threads.md"}},
+		// Answers are searched
+		{"backing array", []string{"/cards/go/slices.md"}},
+		// File names and deck directories are searched
+		{"slices.md", []string{"/cards/go/slices.md"}},
+		{"rust/", []string{"/cards/rust/threads.md"}},
+		// Letters in order match the question fuzzily
+		{"whtapnd", []string{"/cards/go/slices.md"}},
+		{"", nil},
+		{"haskell", nil},
+	}
+
+	for _, test := range tests {
+		got := resultIDs(index.Search(test.query))
+		if !equalIDs(got, test.expected) {
+			t.Errorf("Expected search for %q to find %v, got %v", test.query, test.expected, got)
+		}
+	}
+}
+
+func TestSearchNarrowing(t *testing.T) {
+	index := NewSearchIndex(newSearchStore())
+
+	// Typing further narrows down the previous results
+	queries := []struct {
+		query string
+		found int
+	}{
+		{"g", 3},
+		{"go", 3},
+		{"go ", 3},
+		{"go a", 3},
+		{"go ap", 1},
+		{"go", 3}, // Deleting searches every card again
+		{"thr", 2},
+	}
+	for _, q := range queries {
+		if got := len(index.Search(q.query)); got != q.found {
+			t.Errorf("Expected %d cards found for %q, got %d", q.found, q.query, got)
+		}
+	}
+}
+
+func TestSearchLargeCollection(t *testing.T) {
+	// The collection size GoCard is designed for
+	const cardCount = 10000
+
+	deck := model.Deck{ID: "/cards/large"}
+	for i := 0; i < cardCount; i++ {
+		deck.Cards = append(deck.Cards, model.Card{
+			ID:       fmt.Sprintf("/cards/large/card-%05d.md", i),
+			DeckID:   deck.ID,
+			Question: fmt.Sprintf("Question number %d about topic %d", i, i%97),
+			Answer:   fmt.Sprintf("The answer to question %d explains topic %d in a few sentences of text.", i, i%97),
+			Tags:     []string{fmt.Sprintf("topic-%d", i%97)},
+		})
+	}
+	index := NewSearchIndex(&Store{Decks: []model.Deck{deck}})
+
+	// Search as each letter of a query is typed
+	start := time.Now()
+	var results []SearchResult
+	for _, query := range []string{"t", "to", "top", "topi", "topic", "topic-", "topic-4", "topic-42"} {
+		results = index.Search(query)
+	}
+	elapsed := time.Since(start)
+
+	if len(results) == 0 || results[0].Card.Tags[0] != "topic-42" {
+		t.Errorf("Expected cards tagged topic-42 first, got %d results", len(results))
+	}
+	if elapsed > time.Second {
+		t.Errorf("Expected typing a query over %d cards to take under a second, took %v", cardCount, elapsed)
+	}
+}
@@ -3,39 +3,80 @@
 package data
 
 import (
+	"errors"
 	"fmt"
+	"math/rand/v2"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strings"
 	"time"
 
+	"github.com/DavidMiserak/GoCard/internal/clock"
 	"github.com/DavidMiserak/GoCard/internal/model"
 	"github.com/DavidMiserak/GoCard/internal/srs"
 )
 
 // Store manages all data for the application
 type Store struct {
-	Decks []model.Deck
+	Decks   []model.Deck
+	History []model.ReviewEvent // Every review recorded, oldest first
+	Config  Config
+	Report  LoadReport // Problems found while loading the collection
+
+	dir            string                   // Directory of the collection, empty for the sample decks
+	clock          clock.Clock              // Source of the current time
+	rand           *rand.Rand               // Source of the interval fuzz
+	scheduler      srs.Scheduler            // Scheduler used for decks without their own
+	deckSchedulers map[string]srs.Scheduler // Schedulers of decks with their own config
+	deckConfigs    map[string]Config        // Settings of decks with their own config
+	studiedBefore  map[string][]time.Time   // When the deck was last studied before each review of a card, for undo
 }
 
 // NewStore creates a new data store with dummy data
 func NewStore() *Store {
+	return NewStoreWithClock(clock.Real{})
+}
+
+// NewStoreWithClock creates a new data store with dummy data relative to
+// the time of the given clock
+func NewStoreWithClock(c clock.Clock) *Store {
 	store := &Store{
-		Decks: []model.Deck{},
+		Decks:  []model.Deck{},
+		Config: DefaultConfig(),
+		clock:  c,
 	}
+	store.scheduler, _ = store.Config.NewScheduler() // The default config is valid
 
 	// Add dummy data
-	store.Decks = GetDummyDecks()
+	store.Decks = GetDummyDecksAt(store.Now())
+	store.History = GetDummyHistory(store.Decks)
 
 	return store
 }
 
 // NewStoreFromDir creates a new data store with decks from the specified directory
 func NewStoreFromDir(dirPath string) (*Store, error) {
+	return NewStoreFromDirWithClock(dirPath, clock.Real{})
+}
+
+// NewStoreFromDirWithClock creates a new data store with decks from the
+// specified directory, telling the time with the given clock
+func NewStoreFromDirWithClock(dirPath string, c clock.Clock) (*Store, error) {
 	store := &Store{
-		Decks: []model.Deck{},
+		Decks:          []model.Deck{},
+		Config:         DefaultConfig(),
+		dir:            dirPath,
+		clock:          c,
+		deckSchedulers: make(map[string]srs.Scheduler),
+		deckConfigs:    make(map[string]Config),
 	}
+	now := store.Now()
+
+	// Load the collection settings
+	config, err := LoadConfig(dirPath)
+	store.Report.Add(filepath.Join(dirPath, ConfigFileName), err) // Default settings are used instead
+	store.Config = config
+	store.scheduler, _ = config.NewScheduler() // The config has been validated
 
 	// List all subdirectories (each will be a deck)
 	subdirs, err := listSubdirectories(dirPath)
@@ -45,35 +86,177 @@ func NewStoreFromDir(dirPath string) (*Store, error) {
 
 	// If no subdirectories found, treat the main directory as a single deck
 	if len(subdirs) == 0 {
-		deck, err := CreateDeckFromDir(dirPath)
+		deck, err := createDeckFromDir(dirPath, now, &store.Report)
 		if err != nil {
 			return nil, fmt.Errorf("error creating deck from directory: %w", err)
 		}
 		store.Decks = append(store.Decks, *deck)
+		store.loadHistory(deck.ID)
 		return store, nil
 	}
 
-	// Create decks from each subdirectory
+	// Create decks from each subdirectory, with their own subdirectories
+	// as sub-decks
 	for _, subdir := range subdirs {
-		deck, err := CreateDeckFromDir(subdir)
-		if err != nil {
-			// Log the error but continue with other subdirectories
-			fmt.Printf("Warning: Error loading deck from %s: %v\n", subdir, err)
-			continue
-		}
-		store.Decks = append(store.Decks, *deck)
+		store.loadDeckTree(subdir, "", store.Config, now)
 	}
 
 	// If no decks were loaded, use dummy data
 	if len(store.Decks) == 0 {
-		fmt.Println("No decks found in the specified directory. Using dummy data instead.")
-		store.Decks = GetDummyDecks()
+		store.Report.Add(dirPath, fmt.Errorf("no decks found, showing sample decks instead"))
+		store.Decks = GetDummyDecksAt(now)
+		store.History = GetDummyHistory(store.Decks)
 	}
 
 	return store, nil
 }
 
-// listSubdirectories lists all immediate subdirectories in the given path
+// loadDeckTree loads the deck in deckDir and, recursively, the sub-decks in
+// its subdirectories. Sub-decks are added right after their parent and
+// inherit its config unless they have their own.
+func (s *Store) loadDeckTree(deckDir, parentID string, parentConfig Config, now time.Time) {
+	deck, err := createDeckFromDir(deckDir, now, &s.Report)
+	if err != nil {
+		// Report the error but continue with other subdirectories
+		s.Report.Add(deckDir, err)
+		return
+	}
+	deck.ParentID = parentID
+	s.Decks = append(s.Decks, *deck)
+	s.loadHistory(deck.ID)
+	config := s.loadDeckConfig(deck.ID, parentConfig)
+
+	subdirs, err := listSubdirectories(deckDir)
+	if err != nil {
+		s.Report.Add(deckDir, fmt.Errorf("error listing sub-decks: %w", err))
+		return
+	}
+	for _, subdir := range subdirs {
+		s.loadDeckTree(subdir, deck.ID, config, now)
+	}
+}
+
+// loadHistory appends the review log of a deck directory to the store's history
+func (s *Store) loadHistory(deckDir string) {
+	events, err := LoadReviewHistory(deckDir)
+	s.Report.Add(historyPath(deckDir), err) // Whatever was read before the error is kept
+	s.History = append(s.History, events...)
+}
+
+// loadDeckConfig sets up the settings and scheduler of a deck whose config
+// differs from the collection's, either from its own config file or
+// inherited from a parent deck, and returns the deck's config
+func (s *Store) loadDeckConfig(deckDir string, parentConfig Config) Config {
+	config, _, err := LoadDeckConfig(deckDir, parentConfig)
+	s.Report.Add(filepath.Join(deckDir, ConfigFileName), err)
+	if config == s.Config {
+		return config
+	}
+
+	if s.deckConfigs == nil {
+		s.deckConfigs = make(map[string]Config)
+	}
+	s.deckConfigs[deckDir] = config
+
+	if s.deckSchedulers == nil {
+		s.deckSchedulers = make(map[string]srs.Scheduler)
+	}
+	s.deckSchedulers[deckDir], _ = config.NewScheduler() // The config has been validated
+	return config
+}
+
+// ConfigForDeck returns the settings that apply to the given deck
+func (s *Store) ConfigForDeck(deckID string) Config {
+	if config, ok := s.deckConfigs[deckID]; ok {
+		return config
+	}
+	return s.Config
+}
+
+// Now returns the current time according to the store's clock
+func (s *Store) Now() time.Time {
+	if s.clock == nil {
+		return time.Now()
+	}
+	return s.clock.Now()
+}
+
+// SetClock changes the clock the store tells the time with
+func (s *Store) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
+// Rand returns the random source the store fuzzes intervals with, seeded
+// from the current time unless one was set
+func (s *Store) Rand() *rand.Rand {
+	if s.rand == nil {
+		s.rand = rand.New(rand.NewPCG(uint64(s.Now().UnixNano()), 0))
+	}
+	return s.rand
+}
+
+// SetRand changes the random source the store fuzzes intervals with, so that
+// tests can seed it
+func (s *Store) SetRand(r *rand.Rand) {
+	s.rand = r
+}
+
+// StartOfDay returns when the day containing t began, in the time zone of
+// the store's clock and with days starting at the configured hour
+func (s *Store) StartOfDay(t time.Time) time.Time {
+	return clock.StartOfDay(t, s.Now().Location(), s.Config.DayStartHour)
+}
+
+// Today returns when the current day began
+func (s *Store) Today() time.Time {
+	return s.StartOfDay(s.Now())
+}
+
+// DaysFromToday returns the number of days from today to the day containing
+// t: 0 for today, 1 for tomorrow and negative values for past days
+func (s *Store) DaysFromToday(t time.Time) int {
+	return clock.DaysBetween(s.Today(), s.StartOfDay(t))
+}
+
+// IsDue reports whether a card is due for review. Cards are due for the
+// whole day they are scheduled on, not only from their exact review time,
+// except cards in learning, which are due once their step is over.
+// Suspended and buried cards are not due.
+func (s *Store) IsDue(card model.Card) bool {
+	if s.isSetAside(card) {
+		return false
+	}
+	if card.IsLearning() {
+		return !card.NextReview.After(s.Now())
+	}
+	return card.NextReview.IsZero() || s.DaysFromToday(card.NextReview) <= 0
+}
+
+// Scheduler returns the scheduler used for decks without their own config
+func (s *Store) Scheduler() srs.Scheduler {
+	if s.scheduler == nil {
+		return srs.SM2Scheduler{}
+	}
+	return s.scheduler
+}
+
+// SetScheduler makes every deck use the given scheduler, including decks
+// that select their own in a config file
+func (s *Store) SetScheduler(scheduler srs.Scheduler) {
+	s.scheduler = scheduler
+	s.deckSchedulers = make(map[string]srs.Scheduler)
+}
+
+// SchedulerForDeck returns the scheduler used for cards of the given deck
+func (s *Store) SchedulerForDeck(deckID string) srs.Scheduler {
+	if scheduler, ok := s.deckSchedulers[deckID]; ok {
+		return scheduler
+	}
+	return s.Scheduler()
+}
+
+// listSubdirectories lists all immediate subdirectories in the given path,
+// leaving out hidden ones such as .git
 func listSubdirectories(dirPath string) ([]string, error) {
 	var subdirs []string
 
@@ -83,7 +266,7 @@ func listSubdirectories(dirPath string) ([]string, error) {
 	}
 
 	for _, entry := range entries {
-		if entry.IsDir() {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
 			subdirPath := filepath.Join(dirPath, entry.Name())
 			subdirs = append(subdirs, subdirPath)
 		}
@@ -107,14 +290,58 @@ func (s *Store) GetDeck(id string) (model.Deck, bool) {
 	return model.Deck{}, false
 }
 
+// GetCard returns a card by ID
+func (s *Store) GetCard(id string) (model.Card, bool) {
+	for _, deck := range s.Decks {
+		for _, card := range deck.Cards {
+			if card.ID == id {
+				return card, true
+			}
+		}
+	}
+	return model.Card{}, false
+}
+
+// GetSubDecks returns the decks directly below the given deck
+func (s *Store) GetSubDecks(id string) []model.Deck {
+	var subDecks []model.Deck
+	for _, deck := range s.Decks {
+		if deck.ParentID == id && id != "" {
+			subDecks = append(subDecks, deck)
+		}
+	}
+	return subDecks
+}
+
+// GetDeckWithSubDecks returns a deck whose cards include those of all its
+// sub-decks, at any depth. The cards keep the ID of the deck they belong to,
+// and LastStudied is the latest of the whole tree.
+func (s *Store) GetDeckWithSubDecks(id string) (model.Deck, bool) {
+	deck, found := s.GetDeck(id)
+	if !found {
+		return model.Deck{}, false
+	}
+
+	// Copy the cards so the deck in the store is not changed
+	deck.Cards = append([]model.Card(nil), deck.Cards...)
+	for _, subDeck := range s.GetSubDecks(id) {
+		subTree, _ := s.GetDeckWithSubDecks(subDeck.ID)
+		deck.Cards = append(deck.Cards, subTree.Cards...)
+		if subTree.LastStudied.After(deck.LastStudied) {
+			deck.LastStudied = subTree.LastStudied
+		}
+	}
+
+	return deck, true
+}
+
 // GetDueCards returns cards due for review
 func (s *Store) GetDueCards() []model.Card {
 	var dueCards []model.Card
-	now := time.Now()
 
 	for _, deck := range s.Decks {
 		for _, card := range deck.Cards {
-			if card.NextReview.Before(now) {
+			if s.IsDue(card) {
 				dueCards = append(dueCards, card)
 			}
 		}
@@ -123,19 +350,54 @@ func (s *Store) GetDueCards() []model.Card {
 	return dueCards
 }
 
-// GetDueCardsForDeck returns cards due for review in a specific deck
-func (s *Store) GetDueCardsForDeck(deckID string) []model.Card {
-	var dueCards []model.Card
-	now := time.Now()
+// DueCounts is the number of cards due on a day
+type DueCounts struct {
+	Reviews  int // Cards that have been reviewed before
+	NewCards int // Cards that were never reviewed
+}
+
+// Total returns the number of cards due
+func (c DueCounts) Total() int {
+	return c.Reviews + c.NewCards
+}
 
+// DueCountsByDay counts the cards due on each of the given number of days,
+// starting with the day containing from. Overdue cards are not counted.
+func (s *Store) DueCountsByDay(from time.Time, days int) []DueCounts {
+	counts := make([]DueCounts, max(days, 0))
+
+	firstDay := s.DaysFromToday(from)
 	for _, deck := range s.Decks {
-		if deck.ID == deckID {
-			for _, card := range deck.Cards {
-				if card.NextReview.Before(now) {
-					dueCards = append(dueCards, card)
-				}
+		for _, card := range deck.Cards {
+			if card.NextReview.IsZero() {
+				continue
+			}
+
+			i := s.DaysFromToday(card.NextReview) - firstDay
+			if i < 0 || i >= days {
+				continue
+			}
+
+			if card.Interval > 0 {
+				counts[i].Reviews++
+			} else {
+				counts[i].NewCards++
 			}
-			break
+		}
+	}
+
+	return counts
+}
+
+// GetDueCardsForDeck returns cards due for review in a specific deck,
+// including its sub-decks
+func (s *Store) GetDueCardsForDeck(deckID string) []model.Card {
+	var dueCards []model.Card
+
+	deck, _ := s.GetDeckWithSubDecks(deckID)
+	for _, card := range deck.Cards {
+		if s.IsDue(card) {
+			dueCards = append(dueCards, card)
 		}
 	}
 
@@ -163,7 +425,7 @@ func (s *Store) UpdateCard(updatedCard model.Card) bool {
 func (s *Store) UpdateDeckLastStudied(deckID string) bool {
 	for i, deck := range s.Decks {
 		if deck.ID == deckID {
-			s.Decks[i].LastStudied = time.Now()
+			s.Decks[i].LastStudied = s.Now()
 			return true
 		}
 	}
@@ -172,29 +434,216 @@ func (s *Store) UpdateDeckLastStudied(deckID string) bool {
 
 // SaveCardReview updates a card with its new review data and updates
 // the parent deck's LastStudied timestamp
-func (s *Store) SaveCardReview(card model.Card, rating int) bool {
-	// Use the SRS algorithm to schedule the card
-	updatedCard := srs.ScheduleCard(card, rating)
+func (s *Store) SaveCardReview(card model.Card, rating int) error {
+	return s.SaveCardReviewWithTime(card, rating, 0)
+}
+
+// SaveCardReviewWithTime behaves like SaveCardReview and additionally records
+// how long the user took to answer in the review history. The review is
+// kept in the store even when it cannot be written to the card's file.
+func (s *Store) SaveCardReviewWithTime(card model.Card, rating int, timeToAnswer time.Duration) error {
+	// Use the deck's scheduler to schedule the card
+	updatedCard := s.SchedulerForDeck(card.DeckID).Schedule(card, rating, s.Now())
+	updatedCard = s.fuzz(updatedCard)
+	updatedCard = s.markLeech(card, updatedCard)
+
+	// Update the card in the store and write it through to its file
+	if !s.UpdateCard(updatedCard) {
+		return fmt.Errorf("card not found: %s", card.ID)
+	}
+	saveErr := s.saveCardFile(updatedCard)
+
+	// Update the deck's last studied timestamp, remembering the one it had
+	if deck, found := s.GetDeck(card.DeckID); found {
+		if s.studiedBefore == nil {
+			s.studiedBefore = make(map[string][]time.Time)
+		}
+		s.studiedBefore[card.ID] = append(s.studiedBefore[card.ID], deck.LastStudied)
+	}
+	s.UpdateDeckLastStudied(card.DeckID)
+
+	// Record the review in the history
+	historyErr := s.recordReview(model.ReviewEvent{
+		CardID:       card.ID,
+		DeckID:       card.DeckID,
+		Timestamp:    updatedCard.LastReviewed,
+		Rating:       rating,
+		PrevInterval: card.Interval,
+		NewInterval:  updatedCard.Interval,
+		PrevEase:     card.Ease,
+		NewEase:      updatedCard.Ease,
+		TimeToAnswer: timeToAnswer,
+	})
+
+	return errors.Join(saveErr, historyErr)
+}
+
+// UndoCardReview puts a card back the way it was before its latest review,
+// given that earlier copy of the card, and removes the review from the
+// history and the deck's review log. The deck gets back the time it was
+// last studied before the review.
+func (s *Store) UndoCardReview(previous model.Card) error {
+	if !s.UpdateCard(previous) {
+		return fmt.Errorf("card with ID %s not found", previous.ID)
+	}
+	if times := s.studiedBefore[previous.ID]; len(times) > 0 {
+		if i := s.deckIndex(previous.DeckID); i >= 0 {
+			s.Decks[i].LastStudied = times[len(times)-1]
+		}
+		s.studiedBefore[previous.ID] = times[:len(times)-1]
+	}
+	// Take the review out of the history even if the file is not written,
+	// so the undo is never half done
+	saveErr := s.saveCardFile(previous)
+
+	var historyErr error
+	for i := len(s.History) - 1; i >= 0; i-- {
+		event := s.History[i]
+		if event.CardID != previous.ID {
+			continue
+		}
+
+		s.History = append(s.History[:i:i], s.History[i+1:]...)
+		if isFilePath(event.DeckID) {
+			historyErr = RemoveReviewEvent(event.DeckID, event)
+		}
+		break
+	}
+
+	return errors.Join(saveErr, historyErr)
+}
+
+// CardFile returns the markdown file of a card, and false for cards that
+// have no file, such as the dummy cards
+func (s *Store) CardFile(cardID string) (string, bool) {
+	if !isFilePath(cardID) {
+		return "", false
+	}
+	if _, err := os.Stat(cardID); err != nil {
+		return "", false
+	}
+	return cardID, true
+}
+
+// saveCardFile writes the schedule of a card to the front matter of its
+// markdown file, leaving its question and answer as they are on disk. Cards
+// without a file, such as the dummy cards, are kept in memory only.
+func (s *Store) saveCardFile(card model.Card) error {
+	if !isFilePath(card.ID) {
+		return nil
+	}
+	if _, err := os.Stat(card.ID); os.IsNotExist(err) {
+		return nil
+	}
+	if err := updateCardFrontMatter(card); err != nil {
+		return fmt.Errorf("error saving card %s: %w", card.ID, err)
+	}
+	return nil
+}
+
+// fuzz moves the next review of a card just scheduled to a nearby day when
+// its deck's settings ask for it, the least loaded one with load balancing
+func (s *Store) fuzz(card model.Card) model.Card {
+	config := s.ConfigForDeck(card.DeckID)
+	if !config.Fuzz {
+		return card
+	}
+
+	fuzz := srs.Fuzz{Rand: s.Rand()}
+	if config.LoadBalance {
+		_, longest := srs.FuzzRange(card.Interval)
+		counts := s.DueCountsByDay(s.Now(), longest+1)
+		fuzz.Load = func(days int) int {
+			return counts[days].Total()
+		}
+	}
+
+	return fuzz.Apply(card, s.Now())
+}
+
+// recordReview adds a review event to the in-memory history and appends it
+// to the deck's review log when the deck is backed by a directory. The event
+// stays in memory when it cannot be appended to the log.
+func (s *Store) recordReview(event model.ReviewEvent) error {
+	s.History = append(s.History, event)
+
+	if !isFilePath(event.DeckID) {
+		return nil
+	}
+
+	if err := AppendReviewEvent(event.DeckID, event); err != nil {
+		return fmt.Errorf("error saving review history: %w", err)
+	}
 
-	// Update the card in the store
-	cardUpdated := s.UpdateCard(updatedCard)
+	return nil
+}
 
-	// Update the deck's last studied timestamp
-	deckUpdated := s.UpdateDeckLastStudied(card.DeckID)
+// GetReviewHistory returns every recorded review, oldest first
+func (s *Store) GetReviewHistory() []model.ReviewEvent {
+	return s.History
+}
 
-	return cardUpdated && deckUpdated
+// GetDeckHistory returns the recorded reviews of cards in a deck, oldest first
+func (s *Store) GetDeckHistory(deckID string) []model.ReviewEvent {
+	var events []model.ReviewEvent
+	for _, event := range s.History {
+		if event.DeckID == deckID {
+			events = append(events, event)
+		}
+	}
+	return events
 }
 
-// SaveDeckToMarkdown saves SRS metadata for all cards in a deck back to their markdown files
+// GetDeckHistoryWithSubDecks returns the recorded reviews of the cards of a
+// deck and of all its sub-decks, at any depth
+func (s *Store) GetDeckHistoryWithSubDecks(deckID string) []model.ReviewEvent {
+	inTree := map[string]bool{deckID: true}
+	for _, subDeck := range s.getDescendants(deckID) {
+		inTree[subDeck.ID] = true
+	}
+
+	var events []model.ReviewEvent
+	for _, event := range s.History {
+		if inTree[event.DeckID] {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// GetHistorySince returns the recorded reviews made at or after the given time
+func (s *Store) GetHistorySince(since time.Time) []model.ReviewEvent {
+	var events []model.ReviewEvent
+	for _, event := range s.History {
+		if !event.Timestamp.Before(since) {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// GetCardHistory returns the recorded reviews of a single card, oldest first
+func (s *Store) GetCardHistory(cardID string) []model.ReviewEvent {
+	var events []model.ReviewEvent
+	for _, event := range s.History {
+		if event.CardID == cardID {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// SaveDeckToMarkdown saves SRS metadata for all cards in a deck and its
+// sub-decks back to their markdown files
 func (s *Store) SaveDeckToMarkdown(deckID string) error {
 	// Get the deck from the store
-	deck, found := s.GetDeck(deckID)
+	deck, found := s.GetDeckWithSubDecks(deckID)
 	if !found {
 		return fmt.Errorf("deck with ID %s not found", deckID)
 	}
 
 	// Only proceed if the deck ID looks like a valid directory path
-	if !filepath.IsAbs(deck.ID) && !strings.Contains(deck.ID, "/") && !strings.Contains(deck.ID, "\\") {
+	if !isFilePath(deck.ID) {
 		// This appears to be a dummy deck without proper file paths
 		return nil
 	}
@@ -202,8 +651,7 @@ func (s *Store) SaveDeckToMarkdown(deckID string) error {
 	// For each card in the deck, update its SRS metadata
 	for _, card := range deck.Cards {
 		// Skip cards without a proper file path
-		if card.ID == "" || (!filepath.IsAbs(card.ID) &&
-			!strings.Contains(card.ID, "/") && !strings.Contains(card.ID, "\\")) {
+		if !isFilePath(card.ID) {
 			continue
 		}
 
@@ -213,68 +661,24 @@ func (s *Store) SaveDeckToMarkdown(deckID string) error {
 			continue
 		}
 
-		// Read the existing file content
-		content, err := os.ReadFile(card.ID)
-		if err != nil {
-			return fmt.Errorf("error reading card file %s: %w", card.ID, err)
-		}
-
-		// Parse the content to extract front matter
-		contentStr := string(content)
-		fmStart := strings.Index(contentStr, "---")
-		if fmStart < 0 {
+		// Update only the SRS-specific fields in front matter
+		err := updateCardFrontMatter(card)
+		if errors.Is(err, errNoFrontMatter) {
 			continue // No front matter found
 		}
-
-		fmEnd := strings.Index(contentStr[fmStart+3:], "---")
-		if fmEnd < 0 {
-			continue // Incomplete front matter
-		}
-		fmEnd = fmStart + 3 + fmEnd
-
-		frontMatter := contentStr[fmStart : fmEnd+3]
-		bodyContent := contentStr[fmEnd+3:]
-
-		// Update only the SRS-specific fields in front matter
-		updatedFrontMatter := updateFrontMatterFields(frontMatter, card)
-
-		// Combine updated front matter with original body content
-		updatedContent := updatedFrontMatter + bodyContent
-
-		// Write back to file
-		if err := os.WriteFile(card.ID, []byte(updatedContent), 0644); err != nil {
-			return fmt.Errorf("error writing updated card file %s: %w", card.ID, err)
+		if err != nil {
+			return fmt.Errorf("error updating card file %s: %w", card.ID, err)
 		}
 	}
 
 	return nil
 }
 
-// Helper function to update only SRS-related fields in front matter
-func updateFrontMatterFields(frontMatter string, card model.Card) string {
-	// Regular expressions to update specific fields
-	reviewIntervalRe := regexp.MustCompile(`(review_interval:\s*)[0-9.]+`)
-	difficultyRe := regexp.MustCompile(`(difficulty:\s*)[0-9.]+`)
-	lastReviewedRe := regexp.MustCompile(`(last_reviewed:\s*)[^\n]+`)
-
-	// Format date in the YYYY-MM-DD format
-	lastReviewedFormatted := card.LastReviewed.Format("2006-01-02")
-
-	// Update each field if it exists
-	if reviewIntervalRe.MatchString(frontMatter) {
-		frontMatter = reviewIntervalRe.ReplaceAllString(frontMatter,
-			fmt.Sprintf("${1}%d", card.Interval))
+// isFilePath reports whether an ID looks like a filesystem path rather than
+// the identifier of a dummy deck or card
+func isFilePath(id string) bool {
+	if id == "" {
+		return false
 	}
-
-	if difficultyRe.MatchString(frontMatter) {
-		frontMatter = difficultyRe.ReplaceAllString(frontMatter,
-			fmt.Sprintf("${1}%.1f", card.Ease))
-	}
-
-	if lastReviewedRe.MatchString(frontMatter) {
-		frontMatter = lastReviewedRe.ReplaceAllString(frontMatter,
-			fmt.Sprintf("${1}%s", lastReviewedFormatted))
-	}
-
-	return frontMatter
+	return filepath.IsAbs(id) || strings.Contains(id, "/") || strings.Contains(id, "\\")
 }
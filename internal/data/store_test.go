@@ -0,0 +1,379 @@
+// File: internal/data/store_test.go
+
+package data
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DavidMiserak/GoCard/internal/clock"
+	"github.com/DavidMiserak/GoCard/internal/model"
+	"github.com/DavidMiserak/GoCard/internal/srs"
+)
+
+func TestSaveDeckToMarkdownRoundTrip(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "save-deck")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir) //nolint:errcheck
+
+	// A new card whose front matter has no scheduling fields yet
+	cardPath := filepath.Join(tempDir, "card.md")
+	content := "---\ntags: [go]\n---\n\n# Question\n\nQ\n\n## Answer\n\nA\n"
+	if err := os.WriteFile(cardPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write card: %v", err)
+	}
+
+	now := time.Date(2025, 3, 31, 21, 45, 0, 0, time.FixedZone("UTC+2", 2*60*60))
+	store, err := NewStoreFromDirWithClock(tempDir, clock.Fixed(now))
+	if err != nil {
+		t.Fatalf("NewStoreFromDir error: %v", err)
+	}
+
+	card := store.Decks[0].Cards[0]
+	if !card.LastReviewed.IsZero() || card.Rating != 0 {
+		t.Errorf("Expected a new card to have no review, got %+v", card)
+	}
+
+	if err := store.SaveCardReview(card, 3); err != nil {
+		t.Fatalf("SaveCardReview error: %v", err)
+	}
+	if err := store.SaveDeckToMarkdown(tempDir); err != nil {
+		t.Fatalf("SaveDeckToMarkdown error: %v", err)
+	}
+
+	// Reloading must give back exactly the schedule that was saved
+	saved := store.Decks[0].Cards[0]
+	reloaded, err := ParseMarkdownFile(cardPath)
+	if err != nil {
+		t.Fatalf("ParseMarkdownFile error: %v", err)
+	}
+	got := reloaded.ToModelCardAt(tempDir, now.AddDate(0, 1, 0))
+
+	if !got.LastReviewed.Equal(saved.LastReviewed) {
+		t.Errorf("Expected last reviewed %v, got %v", saved.LastReviewed, got.LastReviewed)
+	}
+	if !got.NextReview.Equal(saved.NextReview) {
+		t.Errorf("Expected next review %v, got %v", saved.NextReview, got.NextReview)
+	}
+	if got.Rating != 3 || got.Interval != saved.Interval || got.Ease != saved.Ease {
+		t.Errorf("Expected rating 3, interval %d and ease %v, got %+v", saved.Interval, saved.Ease, got)
+	}
+	if len(reloaded.FrontMatter.Tags) != 1 || reloaded.Question != "Q" {
+		t.Errorf("Expected the rest of the card to be preserved, got %+v", reloaded)
+	}
+}
+
+func TestSaveCardReviewWritesThrough(t *testing.T) {
+	tempDir := t.TempDir()
+	cardPath := filepath.Join(tempDir, "card.md")
+	content := "---\ntags: [go]\n---\n\n# Question\n\nQ\n\n## Answer\n\nA\n"
+	if err := os.WriteFile(cardPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write card: %v", err)
+	}
+
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	store, err := NewStoreFromDirWithClock(tempDir, clock.Fixed(now))
+	if err != nil {
+		t.Fatalf("NewStoreFromDir error: %v", err)
+	}
+	loadCard := func() model.Card {
+		parsed, err := ParseMarkdownFile(cardPath)
+		if err != nil {
+			t.Fatalf("ParseMarkdownFile error: %v", err)
+		}
+		return parsed.ToModelCardAt(tempDir, now)
+	}
+
+	// The review is in the file as soon as it is saved
+	card := store.Decks[0].Cards[0]
+	if err := store.SaveCardReview(card, 4); err != nil {
+		t.Fatalf("SaveCardReview error: %v", err)
+	}
+	if got := loadCard(); !got.LastReviewed.Equal(now) || got.Rating != 4 {
+		t.Errorf("Expected the review to be written right away, got %+v", got)
+	}
+
+	// Undoing the only review makes the file a new card again
+	if err := store.UndoCardReview(card); err != nil {
+		t.Fatalf("UndoCardReview error: %v", err)
+	}
+	if got := loadCard(); !got.LastReviewed.IsZero() || got.State != model.StateNew || got.Rating != 0 {
+		t.Errorf("Expected a new card after the undo, got %+v", got)
+	}
+
+	// A card edited on disk and rated before it is reloaded keeps the edit
+	written, _ := os.ReadFile(cardPath)
+	edited := strings.Replace(string(written), "\nQ\n", "\nEdited\n", 1)
+	if err := os.WriteFile(cardPath, []byte(edited), 0644); err != nil {
+		t.Fatalf("Failed to edit card: %v", err)
+	}
+	if err := store.SaveCardReview(card, 4); err != nil {
+		t.Fatalf("SaveCardReview error: %v", err)
+	}
+	if got := loadCard(); got.Question != "Edited" || got.Rating != 4 {
+		t.Errorf("Expected the edited question with the review, got %+v", got)
+	}
+
+	// A review that cannot be written is kept in memory and reported
+	if err := os.WriteFile(cardPath, []byte("# Question\nQ\n"), 0644); err != nil {
+		t.Fatalf("Failed to break card: %v", err)
+	}
+	card, _ = store.GetCard(cardPath)
+	if err := store.SaveCardReview(card, 5); err == nil {
+		t.Error("Expected an error saving to a file without front matter")
+	}
+	if reviewed, _ := store.GetCard(cardPath); reviewed.Rating != 5 {
+		t.Errorf("Expected the review in the store, got %+v", reviewed)
+	}
+}
+
+func TestStoreDeckSchedulers(t *testing.T) {
+	rootDir, err := os.MkdirTemp("", "store-schedulers")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(rootDir) //nolint:errcheck
+
+	// The collection uses FSRS, except for one deck that stays on SM-2
+	fsrsDeck := filepath.Join(rootDir, "fsrs-deck")
+	sm2Deck := filepath.Join(rootDir, "sm2-deck")
+	files := map[string]string{
+		filepath.Join(rootDir, ConfigFileName): "scheduler: fsrs\n",
+		filepath.Join(sm2Deck, ConfigFileName): "scheduler: sm2\n",
+		filepath.Join(fsrsDeck, "card.md"):     "---\ntags: []\n---\n# Question\nQ\n# Answer\nA\n",
+		filepath.Join(sm2Deck, "card.md"):      "---\ntags: []\n---\n# Question\nQ\n# Answer\nA\n",
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+
+	store, err := NewStoreFromDir(rootDir)
+	if err != nil {
+		t.Fatalf("NewStoreFromDir error: %v", err)
+	}
+
+	if name := store.SchedulerForDeck(fsrsDeck).Name(); name != srs.SchedulerFSRS {
+		t.Errorf("Expected deck without config to use %q, got %q", srs.SchedulerFSRS, name)
+	}
+	if name := store.SchedulerForDeck(sm2Deck).Name(); name != srs.SchedulerSM2 {
+		t.Errorf("Expected deck with its own config to use %q, got %q", srs.SchedulerSM2, name)
+	}
+
+	// Overriding the scheduler applies to every deck
+	store.SetScheduler(srs.SM2Scheduler{})
+	if name := store.SchedulerForDeck(fsrsDeck).Name(); name != srs.SchedulerSM2 {
+		t.Errorf("Expected override to apply to every deck, got %q", name)
+	}
+
+	// A store created without a scheduler falls back to SM-2
+	if name := (&Store{}).Scheduler().Name(); name != srs.SchedulerSM2 {
+		t.Errorf("Expected zero-value store to use %q, got %q", srs.SchedulerSM2, name)
+	}
+}
+
+func TestStoreClock(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	store := &Store{
+		Decks: []model.Deck{
+			{
+				ID: "deck",
+				Cards: []model.Card{
+					{ID: "due", DeckID: "deck", NextReview: now.Add(-time.Hour), Ease: 2.5},
+					{ID: "later", DeckID: "deck", NextReview: now.AddDate(0, 0, 1), Ease: 2.5},
+				},
+			},
+		},
+	}
+	store.SetClock(clock.Fixed(now))
+
+	due := store.GetDueCards()
+	if len(due) != 1 || due[0].ID != "due" {
+		t.Fatalf("Expected only the card due by %v, got %+v", now, due)
+	}
+	if got := len(store.GetDueCardsForDeck("deck")); got != 1 {
+		t.Errorf("Expected 1 due card in deck, got %d", got)
+	}
+
+	// Reviews are timestamped and scheduled with the store's clock
+	if err := store.SaveCardReview(due[0], 4); err != nil {
+		t.Fatalf("SaveCardReview error: %v", err)
+	}
+
+	reviewed := store.Decks[0].Cards[0]
+	if !reviewed.LastReviewed.Equal(now) {
+		t.Errorf("Expected card reviewed at %v, got %v", now, reviewed.LastReviewed)
+	}
+	if !reviewed.NextReview.Equal(now.AddDate(0, 0, reviewed.Interval)) {
+		t.Errorf("Expected next review %d days after %v, got %v", reviewed.Interval, now, reviewed.NextReview)
+	}
+	if !store.Decks[0].LastStudied.Equal(now) {
+		t.Errorf("Expected deck last studied at %v, got %v", now, store.Decks[0].LastStudied)
+	}
+	if history := store.GetReviewHistory(); len(history) != 1 || !history[0].Timestamp.Equal(now) {
+		t.Errorf("Expected one review recorded at %v, got %+v", now, history)
+	}
+}
+
+func TestStoreFuzzLoadBalancing(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	reviewed := now.AddDate(0, 0, -10)
+
+	// Cards already due in 26 to 33 days, except 29 days from now
+	var cards []model.Card
+	for days := 26; days <= 33; days++ {
+		if days != 29 {
+			cards = append(cards, model.Card{
+				ID: fmt.Sprintf("due-in-%d", days), DeckID: "deck", LastReviewed: reviewed,
+				NextReview: now.AddDate(0, 0, days), Interval: days, Ease: 2.5,
+			})
+		}
+	}
+	card := model.Card{ID: "card", DeckID: "deck", LastReviewed: reviewed, NextReview: now, Interval: 10, Ease: 3}
+	cards = append(cards, card)
+
+	config := DefaultConfig()
+	config.Fuzz = true
+	store := &Store{Config: config, Decks: []model.Deck{{ID: "deck", Cards: cards}}}
+	store.SetClock(clock.Fixed(now))
+	store.SetRand(rand.New(rand.NewPCG(1, 2)))
+
+	// Good schedules the card in 30 days, moved to the free day in range
+	if err := store.SaveCardReview(card, 4); err != nil {
+		t.Fatalf("SaveCardReview error: %v", err)
+	}
+	reviewedCard, _ := store.GetCard("card")
+	if reviewedCard.Interval != 29 || !reviewedCard.NextReview.Equal(now.AddDate(0, 0, 29)) {
+		t.Errorf("Expected the review on the least loaded day, 29 days from now, got %d days, due %v",
+			reviewedCard.Interval, reviewedCard.NextReview)
+	}
+
+	counts := store.DueCountsByDay(now, 40)
+	if counts[29].Reviews != 1 || counts[30].Reviews != 1 || counts[0].Total() != 0 {
+		t.Errorf("Expected one review due 29 and 30 days from now and none today, got %+v", counts)
+	}
+}
+
+func TestStoreDayBoundary(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	now := time.Date(2025, 3, 31, 2, 30, 0, 0, loc)
+
+	store := &Store{Config: Config{DayStartHour: 4}}
+	store.SetClock(clock.Fixed(now))
+
+	// At 02:30 the day that started at 04:00 yesterday is still going on
+	if expected := time.Date(2025, 3, 30, 4, 0, 0, 0, loc); !store.Today().Equal(expected) {
+		t.Errorf("Expected today to start at %v, got %v", expected, store.Today())
+	}
+
+	testCases := []struct {
+		name       string
+		nextReview time.Time
+		due        bool
+	}{
+		{"Later the same day", now.Add(time.Hour), true},
+		{"Late tonight in UTC", time.Date(2025, 3, 31, 1, 0, 0, 0, time.UTC), true},
+		{"After the new day starts", time.Date(2025, 3, 31, 4, 0, 0, 0, loc), false},
+		{"Yesterday", now.AddDate(0, 0, -1), true},
+		{"Never scheduled", time.Time{}, true},
+	}
+
+	for _, tc := range testCases {
+		if got := store.IsDue(model.Card{NextReview: tc.nextReview}); got != tc.due {
+			t.Errorf("%s: expected due to be %v, got %v", tc.name, tc.due, got)
+		}
+	}
+
+	if got := store.DaysFromToday(time.Date(2025, 4, 2, 12, 0, 0, 0, loc)); got != 3 {
+		t.Errorf("Expected Apr 2 to be 3 days from today, got %d", got)
+	}
+}
+
+func TestNewStoreFromDirNestedDecks(t *testing.T) {
+	rootDir := t.TempDir()
+
+	card := "---\ntags: []\n---\n# Question\nQ\n# Answer\nA\n"
+	programming := filepath.Join(rootDir, "programming")
+	goDeck := filepath.Join(programming, "go")
+	concurrency := filepath.Join(goDeck, "concurrency")
+	files := map[string]string{
+		filepath.Join(programming, "card.md"):         card,
+		filepath.Join(goDeck, "card.md"):              card,
+		filepath.Join(goDeck, ConfigFileName):         "scheduler: fsrs\n",
+		filepath.Join(concurrency, "card.md"):         card,
+		filepath.Join(rootDir, "spanish", "card.md"):  card,
+		filepath.Join(rootDir, ".git", "HEAD.md"):     card,
+		filepath.Join(concurrency, "channels.md"):     card,
+		filepath.Join(rootDir, "spanish", "verbs.md"): card,
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+
+	store, err := NewStoreFromDir(rootDir)
+	if err != nil {
+		t.Fatalf("NewStoreFromDir error: %v", err)
+	}
+
+	// Sub-decks follow their parent and hidden directories are skipped
+	expected := []struct {
+		id       string
+		parentID string
+		cards    int
+	}{
+		{programming, "", 1},
+		{goDeck, programming, 1},
+		{concurrency, goDeck, 2},
+		{filepath.Join(rootDir, "spanish"), "", 2},
+	}
+	if len(store.Decks) != len(expected) {
+		t.Fatalf("Expected %d decks, got %d", len(expected), len(store.Decks))
+	}
+	for i, e := range expected {
+		deck := store.Decks[i]
+		if deck.ID != e.id || deck.ParentID != e.parentID || len(deck.Cards) != e.cards {
+			t.Errorf("Expected deck %s with parent %q and %d cards, got %s with parent %q and %d cards",
+				e.id, e.parentID, e.cards, deck.ID, deck.ParentID, len(deck.Cards))
+		}
+	}
+
+	if subDecks := store.GetSubDecks(programming); len(subDecks) != 1 || subDecks[0].ID != goDeck {
+		t.Errorf("Expected %s to be the only sub-deck of %s, got %+v", goDeck, programming, subDecks)
+	}
+
+	// A parent deck includes the cards of all its descendants
+	tree, found := store.GetDeckWithSubDecks(programming)
+	if !found || len(tree.Cards) != 4 {
+		t.Errorf("Expected 4 cards in the programming tree, got %d", len(tree.Cards))
+	}
+	if got := len(store.GetDueCardsForDeck(programming)); got != 4 {
+		t.Errorf("Expected 4 due cards in the programming tree, got %d", got)
+	}
+	if deck, _ := store.GetDeck(programming); len(deck.Cards) != 1 {
+		t.Errorf("Expected the stored deck to keep only its own cards, got %d", len(deck.Cards))
+	}
+
+	// Sub-decks inherit the config of their parent
+	if name := store.SchedulerForDeck(concurrency).Name(); name != srs.SchedulerFSRS {
+		t.Errorf("Expected sub-deck to inherit %q, got %q", srs.SchedulerFSRS, name)
+	}
+	if name := store.SchedulerForDeck(programming).Name(); name != srs.SchedulerSM2 {
+		t.Errorf("Expected parent deck to keep %q, got %q", srs.SchedulerSM2, name)
+	}
+}
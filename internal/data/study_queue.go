@@ -0,0 +1,284 @@
+// File: internal/data/study_queue.go
+
+package data
+
+import (
+	"math/rand/v2"
+	"sort"
+
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+// Interleave modes, setting the order of cards when studying all decks
+const (
+	InterleaveDeck       = "deck"        // One deck after the other
+	InterleaveRoundRobin = "round-robin" // One card of each deck in turn
+	InterleaveRandom     = "random"      // Shuffled across decks
+)
+
+// InterleaveModes returns the names of the available interleave modes
+func InterleaveModes() []string {
+	return []string{InterleaveDeck, InterleaveRoundRobin, InterleaveRandom}
+}
+
+// isInterleaveMode reports whether mode is a known interleave mode. An
+// empty mode means one deck after the other.
+func isInterleaveMode(mode string) bool {
+	for _, known := range InterleaveModes() {
+		if mode == known {
+			return true
+		}
+	}
+	return mode == ""
+}
+
+// isNewCard reports whether a card has never been reviewed
+func isNewCard(card model.Card) bool {
+	return card.LastReviewed.IsZero()
+}
+
+// dailyCounts is the number of new cards and reviews studied on a day
+type dailyCounts struct {
+	newCards int
+	reviews  int
+}
+
+// studiedToday counts, for each deck, the cards studied since the day
+// started. A card counts as new when its first recorded review was today,
+// and each card is counted once however often it was reviewed.
+func (s *Store) studiedToday() map[string]dailyCounts {
+	today := s.Today()
+
+	firstReview := make(map[string]bool) // Whether the first review of a card was today
+	counted := make(map[string]bool)
+	counts := make(map[string]dailyCounts)
+
+	for _, event := range s.History {
+		if _, seen := firstReview[event.CardID]; !seen {
+			firstReview[event.CardID] = !event.Timestamp.Before(today)
+		}
+		if event.Timestamp.Before(today) || counted[event.CardID] {
+			continue
+		}
+		counted[event.CardID] = true
+
+		deckCounts := counts[event.DeckID]
+		if firstReview[event.CardID] {
+			deckCounts.newCards++
+		} else {
+			deckCounts.reviews++
+		}
+		counts[event.DeckID] = deckCounts
+	}
+
+	return counts
+}
+
+// withinLimit returns the first cards that fit in a daily limit of which
+// done cards were already studied
+func withinLimit(cards []model.Card, limit, done int) []model.Card {
+	left := max(limit-done, 0)
+	return cards[:min(len(cards), left)]
+}
+
+// StudyQueue returns the cards to study today in a deck and its sub-decks:
+// the cards in learning whose step is over, then the due reviews, most
+// overdue first, followed by new cards. Each deck contributes at most the
+// new cards and reviews its daily limits leave, and the limits of the
+// studied deck also cap the session as a whole. Cards in learning were
+// counted when they were first studied, so they are not limited.
+func (s *Store) StudyQueue(deckID string) []model.Card {
+	return s.studyQueue(deckID, nil)
+}
+
+// studyQueue returns the cards to study today in a deck and its sub-decks
+// like StudyQueue, only considering the cards include accepts. A nil
+// include considers every card.
+func (s *Store) studyQueue(deckID string, include func(model.Card) bool) []model.Card {
+	deck, found := s.GetDeck(deckID)
+	if !found {
+		return nil
+	}
+
+	counts := s.studiedToday()
+
+	var learning, reviews, newCards []model.Card
+	var done dailyCounts
+	for _, d := range append([]model.Deck{deck}, s.getDescendants(deckID)...) {
+		config := s.ConfigForDeck(d.ID)
+		deckDone := counts[d.ID]
+		done.newCards += deckDone.newCards
+		done.reviews += deckDone.reviews
+
+		var deckReviews, deckNew []model.Card
+		for _, card := range d.Cards {
+			switch {
+			case s.isSetAside(card), include != nil && !include(card):
+				continue
+			case isNewCard(card):
+				deckNew = append(deckNew, card)
+			case card.IsLearning():
+				if s.IsDue(card) {
+					learning = append(learning, card)
+				}
+			case s.IsDue(card):
+				deckReviews = append(deckReviews, card)
+			}
+		}
+
+		sortByNextReview(deckReviews)
+		reviews = append(reviews, withinLimit(deckReviews, config.ReviewsPerDay, deckDone.reviews)...)
+		newCards = append(newCards, withinLimit(deckNew, config.NewCardsPerDay, deckDone.newCards)...)
+	}
+
+	// The studied deck's limits apply to the whole tree
+	config := s.ConfigForDeck(deckID)
+	sortByNextReview(reviews)
+	reviews = withinLimit(reviews, config.ReviewsPerDay, done.reviews)
+	newCards = withinLimit(newCards, config.NewCardsPerDay, done.newCards)
+
+	sortByNextReview(learning)
+	return append(append(learning, reviews...), newCards...)
+}
+
+// StudyAheadCards returns the cards of a deck and its sub-decks that are not
+// in today's study queue, the ones due soonest first, for studying ahead
+// once the queue is empty. Suspended and buried cards are left out.
+func (s *Store) StudyAheadCards(deckID string) []model.Card {
+	return s.studyAheadCards(deckID, nil)
+}
+
+// studyAheadCards returns the cards of a deck and its sub-decks to study
+// ahead like StudyAheadCards, only considering the cards include accepts
+func (s *Store) studyAheadCards(deckID string, include func(model.Card) bool) []model.Card {
+	deck, found := s.GetDeckWithSubDecks(deckID)
+	if !found {
+		return nil
+	}
+
+	queued := make(map[string]bool)
+	for _, card := range s.studyQueue(deckID, include) {
+		queued[card.ID] = true
+	}
+
+	var cards []model.Card
+	for _, card := range deck.Cards {
+		if !queued[card.ID] && !s.isSetAside(card) && (include == nil || include(card)) {
+			cards = append(cards, card)
+		}
+	}
+
+	sortByNextReview(cards)
+	return cards
+}
+
+// getDescendants returns the sub-decks of a deck at any depth, each
+// followed by its own sub-decks
+func (s *Store) getDescendants(deckID string) []model.Deck {
+	var descendants []model.Deck
+	for _, subDeck := range s.GetSubDecks(deckID) {
+		descendants = append(descendants, subDeck)
+		descendants = append(descendants, s.getDescendants(subDeck.ID)...)
+	}
+	return descendants
+}
+
+// sortByNextReview orders cards by their next review, keeping the order of
+// cards scheduled at the same time
+func sortByNextReview(cards []model.Card) {
+	sort.SliceStable(cards, func(i, j int) bool {
+		return cards[i].NextReview.Before(cards[j].NextReview)
+	})
+}
+
+// AllDecksStudyQueue returns the cards to study today in every deck, each
+// deck within its daily limits, in the configured interleave order
+func (s *Store) AllDecksStudyQueue() []model.Card {
+	return s.allDecksStudyQueue(nil)
+}
+
+// TagStudyQueue returns the cards matching a tag filter to study today in
+// every deck, like AllDecksStudyQueue. The daily limits apply to the
+// matching cards only.
+func (s *Store) TagStudyQueue(filter TagFilter) []model.Card {
+	return s.allDecksStudyQueue(filter.Match)
+}
+
+// allDecksStudyQueue returns the cards to study today in every deck, only
+// considering the cards include accepts
+func (s *Store) allDecksStudyQueue(include func(model.Card) bool) []model.Card {
+	var cards []model.Card
+	for _, deck := range s.Decks {
+		if deck.ParentID == "" {
+			cards = append(cards, s.studyQueue(deck.ID, include)...)
+		}
+	}
+
+	rng := rand.New(rand.NewPCG(uint64(s.Now().UnixNano()), 0))
+	return interleave(cards, s.Config.Interleave, rng)
+}
+
+// AllDecksStudyAheadCards returns the cards of every deck that are not in
+// today's study queue, the ones due soonest first
+func (s *Store) AllDecksStudyAheadCards() []model.Card {
+	return s.allDecksStudyAheadCards(nil)
+}
+
+// TagStudyAheadCards returns the cards matching a tag filter that are not in
+// today's study queue, the ones due soonest first
+func (s *Store) TagStudyAheadCards(filter TagFilter) []model.Card {
+	return s.allDecksStudyAheadCards(filter.Match)
+}
+
+// allDecksStudyAheadCards returns the cards of every deck to study ahead,
+// only considering the cards include accepts
+func (s *Store) allDecksStudyAheadCards(include func(model.Card) bool) []model.Card {
+	var cards []model.Card
+	for _, deck := range s.Decks {
+		if deck.ParentID == "" {
+			cards = append(cards, s.studyAheadCards(deck.ID, include)...)
+		}
+	}
+
+	sortByNextReview(cards)
+	return cards
+}
+
+// interleave orders cards from several decks. The cards of a deck keep
+// their order, except in random mode.
+func interleave(cards []model.Card, mode string, rng *rand.Rand) []model.Card {
+	if mode == InterleaveRandom {
+		shuffled := append([]model.Card(nil), cards...)
+		rng.Shuffle(len(shuffled), func(i, j int) {
+			shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+		})
+		return shuffled
+	}
+
+	// Group the cards by deck, with decks in the order they first appear
+	var deckOrder []string
+	groups := make(map[string][]model.Card)
+	for _, card := range cards {
+		if _, ok := groups[card.DeckID]; !ok {
+			deckOrder = append(deckOrder, card.DeckID)
+		}
+		groups[card.DeckID] = append(groups[card.DeckID], card)
+	}
+
+	ordered := make([]model.Card, 0, len(cards))
+	if mode == InterleaveRoundRobin {
+		for i := 0; len(ordered) < len(cards); i++ {
+			for _, deckID := range deckOrder {
+				if i < len(groups[deckID]) {
+					ordered = append(ordered, groups[deckID][i])
+				}
+			}
+		}
+		return ordered
+	}
+
+	for _, deckID := range deckOrder {
+		ordered = append(ordered, groups[deckID]...)
+	}
+	return ordered
+}
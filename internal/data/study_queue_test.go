@@ -0,0 +1,227 @@
+// File: internal/data/study_queue_test.go
+
+package data
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/DavidMiserak/GoCard/internal/clock"
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+// cardIDs returns the IDs of cards in order
+func cardIDs(cards []model.Card) []string {
+	ids := make([]string, len(cards))
+	for i, card := range cards {
+		ids[i] = card.ID
+	}
+	return ids
+}
+
+// equalIDs reports whether two lists of IDs are the same
+func equalIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestStudyQueue(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	reviewed := now.AddDate(0, 0, -10)
+
+	review := func(id string, nextReview time.Time) model.Card {
+		return model.Card{ID: id, DeckID: "deck", LastReviewed: reviewed, NextReview: nextReview, Ease: 2.5}
+	}
+	newCard := func(id string) model.Card {
+		return model.Card{ID: id, DeckID: "deck", NextReview: now, Ease: 2.5}
+	}
+
+	store := &Store{
+		Config: Config{NewCardsPerDay: 2, ReviewsPerDay: 2},
+		Decks: []model.Deck{
+			{
+				ID: "deck",
+				Cards: []model.Card{
+					newCard("new-1"),
+					review("due-today", now.Add(time.Hour)),
+					review("later", now.AddDate(0, 0, 3)),
+					newCard("new-2"),
+					review("overdue", now.AddDate(0, 0, -2)),
+					review("over-limit", now.Add(2*time.Hour)),
+					newCard("new-3"),
+				},
+			},
+		},
+	}
+	store.SetClock(clock.Fixed(now))
+
+	// Reviews come first, most overdue first, then new cards, both capped
+	expected := []string{"overdue", "due-today", "new-1", "new-2"}
+	if got := cardIDs(store.StudyQueue("deck")); !equalIDs(got, expected) {
+		t.Errorf("Expected queue %v, got %v", expected, got)
+	}
+
+	// Cards studied earlier today count towards the limits
+	store.History = []model.ReviewEvent{
+		{CardID: "reviewed", DeckID: "deck", Timestamp: reviewed},
+		{CardID: "reviewed", DeckID: "deck", Timestamp: now.Add(-time.Hour)},
+		{CardID: "new-0", DeckID: "deck", Timestamp: now.Add(-2 * time.Hour)},
+		{CardID: "new-0", DeckID: "deck", Timestamp: now.Add(-time.Hour)},
+	}
+	expected = []string{"overdue", "new-1"}
+	if got := cardIDs(store.StudyQueue("deck")); !equalIDs(got, expected) {
+		t.Errorf("Expected queue %v after studying, got %v", expected, got)
+	}
+
+	// Studying ahead goes through the rest, the ones due soonest first
+	expected = []string{"new-2", "new-3", "due-today", "over-limit", "later"}
+	if got := cardIDs(store.StudyAheadCards("deck")); !equalIDs(got, expected) {
+		t.Errorf("Expected cards to study ahead %v, got %v", expected, got)
+	}
+}
+
+func TestStudyQueueLearningCards(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+
+	learning := func(id string, nextReview time.Time) model.Card {
+		return model.Card{
+			ID: id, DeckID: "deck", LastReviewed: nextReview.Add(-10 * time.Minute),
+			NextReview: nextReview, Ease: 2.5, State: model.StateLearning,
+		}
+	}
+
+	store := &Store{
+		Config: Config{NewCardsPerDay: 1, ReviewsPerDay: 0},
+		Decks: []model.Deck{
+			{
+				ID: "deck",
+				Cards: []model.Card{
+					{ID: "new", DeckID: "deck", NextReview: now, Ease: 2.5},
+					learning("in-ten-minutes", now.Add(10*time.Minute)),
+					learning("due", now.Add(-time.Minute)),
+					{ID: "review", DeckID: "deck", LastReviewed: now.AddDate(0, 0, -3), NextReview: now, Ease: 2.5, State: model.StateReview},
+				},
+			},
+		},
+	}
+	store.SetClock(clock.Fixed(now))
+
+	// Cards in learning are due at the end of their step, not for the whole
+	// day, and come first whatever the daily limits
+	expected := []string{"due", "new"}
+	if got := cardIDs(store.StudyQueue("deck")); !equalIDs(got, expected) {
+		t.Errorf("Expected queue %v, got %v", expected, got)
+	}
+
+	store.SetClock(clock.Fixed(now.Add(10 * time.Minute)))
+	expected = []string{"due", "in-ten-minutes", "new"}
+	if got := cardIDs(store.StudyQueue("deck")); !equalIDs(got, expected) {
+		t.Errorf("Expected queue %v ten minutes later, got %v", expected, got)
+	}
+}
+
+func TestStudyQueueSubDeckLimits(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+
+	newCards := func(deckID string, n int) []model.Card {
+		var cards []model.Card
+		for i := 0; i < n; i++ {
+			cards = append(cards, model.Card{ID: deckID + string(rune('a'+i)), DeckID: deckID, NextReview: now})
+		}
+		return cards
+	}
+
+	store := &Store{
+		Config: Config{NewCardsPerDay: 3, ReviewsPerDay: 100},
+		Decks: []model.Deck{
+			{ID: "parent", Cards: newCards("parent", 2)},
+			{ID: "child", ParentID: "parent", Cards: newCards("child", 3)},
+		},
+		deckConfigs: map[string]Config{
+			"child": {NewCardsPerDay: 1, ReviewsPerDay: 100},
+		},
+	}
+	store.SetClock(clock.Fixed(now))
+
+	// The child deck gives a single new card of its own
+	if got := cardIDs(store.StudyQueue("child")); !equalIDs(got, []string{"childa"}) {
+		t.Errorf("Expected the child deck's limit to apply, got %v", got)
+	}
+
+	// The parent's limit caps the whole tree
+	if got := cardIDs(store.StudyQueue("parent")); !equalIDs(got, []string{"parenta", "parentb", "childa"}) {
+		t.Errorf("Expected the parent's limit to cap the tree, got %v", got)
+	}
+}
+
+func TestInterleave(t *testing.T) {
+	cards := []model.Card{
+		{ID: "a1", DeckID: "a"}, {ID: "a2", DeckID: "a"}, {ID: "a3", DeckID: "a"},
+		{ID: "b1", DeckID: "b"},
+		{ID: "c1", DeckID: "c"}, {ID: "c2", DeckID: "c"},
+	}
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	testCases := []struct {
+		mode     string
+		expected []string
+	}{
+		{InterleaveDeck, []string{"a1", "a2", "a3", "b1", "c1", "c2"}},
+		{"", []string{"a1", "a2", "a3", "b1", "c1", "c2"}},
+		{InterleaveRoundRobin, []string{"a1", "b1", "c1", "a2", "c2", "a3"}},
+	}
+	for _, tc := range testCases {
+		if got := cardIDs(interleave(cards, tc.mode, rng)); !equalIDs(got, tc.expected) {
+			t.Errorf("%q: expected %v, got %v", tc.mode, tc.expected, got)
+		}
+	}
+
+	// Random mode shuffles every card exactly once, the same way for the same seed
+	shuffled := interleave(cards, InterleaveRandom, rand.New(rand.NewPCG(1, 2)))
+	again := interleave(cards, InterleaveRandom, rand.New(rand.NewPCG(1, 2)))
+	if !equalIDs(cardIDs(shuffled), cardIDs(again)) {
+		t.Errorf("Expected the same order for the same seed, got %v and %v", cardIDs(shuffled), cardIDs(again))
+	}
+	seen := make(map[string]bool)
+	for _, card := range shuffled {
+		seen[card.ID] = true
+	}
+	if len(shuffled) != len(cards) || len(seen) != len(cards) {
+		t.Errorf("Expected every card once, got %v", cardIDs(shuffled))
+	}
+}
+
+func TestAllDecksStudyQueue(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	due := func(id, deckID string) model.Card {
+		return model.Card{ID: id, DeckID: deckID, LastReviewed: now.AddDate(0, 0, -3), NextReview: now.Add(-time.Hour)}
+	}
+
+	config := DefaultConfig()
+	config.Interleave = InterleaveRoundRobin
+	store := &Store{
+		Config: config,
+		Decks: []model.Deck{
+			{ID: "go", Cards: []model.Card{due("go-1", "go"), due("go-2", "go")}},
+			{ID: "concurrency", ParentID: "go", Cards: []model.Card{due("cc-1", "concurrency")}},
+			{ID: "spanish", Cards: []model.Card{due("es-1", "spanish"), {ID: "es-2", DeckID: "spanish", LastReviewed: now, NextReview: now.AddDate(0, 0, 5)}}},
+		},
+	}
+	store.SetClock(clock.Fixed(now))
+
+	expected := []string{"go-1", "cc-1", "es-1", "go-2"}
+	if got := cardIDs(store.AllDecksStudyQueue()); !equalIDs(got, expected) {
+		t.Errorf("Expected queue %v, got %v", expected, got)
+	}
+	if got := cardIDs(store.AllDecksStudyAheadCards()); !equalIDs(got, []string{"es-2"}) {
+		t.Errorf("Expected to study ahead [es-2], got %v", got)
+	}
+}
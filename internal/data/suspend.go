@@ -0,0 +1,77 @@
+// File: internal/data/suspend.go
+
+package data
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+// IsBuried reports whether a card is buried until a time still to come
+func (s *Store) IsBuried(card model.Card) bool {
+	return card.BuriedUntil.After(s.Now())
+}
+
+// isSetAside reports whether a card is left out of study sessions, because
+// it is suspended or buried
+func (s *Store) isSetAside(card model.Card) bool {
+	return card.Suspended || s.IsBuried(card)
+}
+
+// SuspendCard leaves a card out of study sessions until it is unsuspended
+func (s *Store) SuspendCard(cardID string) error {
+	return s.changeCard(cardID, func(card *model.Card) {
+		card.Suspended = true
+	})
+}
+
+// UnsuspendCard puts a suspended card back into study sessions
+func (s *Store) UnsuspendCard(cardID string) error {
+	return s.changeCard(cardID, func(card *model.Card) {
+		card.Suspended = false
+	})
+}
+
+// BuryCard leaves a card out of study sessions until the next day starts
+func (s *Store) BuryCard(cardID string) error {
+	tomorrow := s.Today().AddDate(0, 0, 1)
+	return s.changeCard(cardID, func(card *model.Card) {
+		card.BuriedUntil = tomorrow
+	})
+}
+
+// UnburyCard puts a buried card back into study sessions right away
+func (s *Store) UnburyCard(cardID string) error {
+	return s.changeCard(cardID, func(card *model.Card) {
+		card.BuriedUntil = time.Time{}
+	})
+}
+
+// SetAsideCards returns the suspended and buried cards of every deck, in
+// deck order
+func (s *Store) SetAsideCards() []model.Card {
+	var cards []model.Card
+	for _, deck := range s.Decks {
+		for _, card := range deck.Cards {
+			if s.isSetAside(card) {
+				cards = append(cards, card)
+			}
+		}
+	}
+	return cards
+}
+
+// changeCard applies a change to a card in the store and saves it to the
+// card's file when the card has one
+func (s *Store) changeCard(cardID string, change func(card *model.Card)) error {
+	card, found := s.GetCard(cardID)
+	if !found {
+		return fmt.Errorf("card not found: %s", cardID)
+	}
+
+	change(&card)
+	s.UpdateCard(card)
+	return s.saveCardFile(card)
+}
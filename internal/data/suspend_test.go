@@ -0,0 +1,86 @@
+// File: internal/data/suspend_test.go
+
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DavidMiserak/GoCard/internal/clock"
+)
+
+func TestSuspendAndBuryCards(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+
+	tempDir := t.TempDir()
+	deckDir := filepath.Join(tempDir, "go")
+	if err := os.Mkdir(deckDir, 0755); err != nil {
+		t.Fatalf("Failed to create deck dir: %v", err)
+	}
+	for _, name := range []string{"a.md", "b.md"} {
+		content := "---\ntags: [go]\n---\n# Question\n" + name + "\n# Answer\nA\n"
+		if err := os.WriteFile(filepath.Join(deckDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write card: %v", err)
+		}
+	}
+
+	store, err := NewStoreFromDirWithClock(tempDir, clock.Fixed(now))
+	if err != nil {
+		t.Fatalf("NewStoreFromDirWithClock error: %v", err)
+	}
+	cardA := filepath.Join(deckDir, "a.md")
+	cardB := filepath.Join(deckDir, "b.md")
+	fileContent := func(path string) string {
+		content, _ := os.ReadFile(path)
+		return string(content)
+	}
+
+	// A suspended card is out of the due cards and the queue, and saved so
+	if err := store.SuspendCard(cardA); err != nil {
+		t.Fatalf("SuspendCard error: %v", err)
+	}
+	if err := store.BuryCard(cardB); err != nil {
+		t.Fatalf("BuryCard error: %v", err)
+	}
+	if due := store.GetDueCardsForDeck(deckDir); len(due) != 0 {
+		t.Errorf("Expected no due cards with both set aside, got %v", cardIDs(due))
+	}
+	if queue := store.StudyQueue(deckDir); len(queue) != 0 {
+		t.Errorf("Expected an empty study queue, got %v", cardIDs(queue))
+	}
+	if !strings.Contains(fileContent(cardA), "suspended: true\n") {
+		t.Errorf("Expected the suspension to be saved, got:\n%s", fileContent(cardA))
+	}
+	if !strings.Contains(fileContent(cardB), "buried_until: 2025-04-01T00:00:00Z\n") {
+		t.Errorf("Expected the card buried until tomorrow, got:\n%s", fileContent(cardB))
+	}
+	if got := cardIDs(store.SetAsideCards()); !equalIDs(got, []string{cardA, cardB}) {
+		t.Errorf("Expected both cards to be listed as set aside, got %v", got)
+	}
+
+	// Buried cards come back the next day, suspended cards stay out
+	store.SetClock(clock.Fixed(now.AddDate(0, 0, 1)))
+	if got := cardIDs(store.StudyQueue(deckDir)); !equalIDs(got, []string{cardB}) {
+		t.Errorf("Expected only the buried card the next day, got %v", got)
+	}
+
+	// Unsuspending and unburying remove the fields again
+	if err := store.UnsuspendCard(cardA); err != nil {
+		t.Fatalf("UnsuspendCard error: %v", err)
+	}
+	if err := store.UnburyCard(cardB); err != nil {
+		t.Fatalf("UnburyCard error: %v", err)
+	}
+	for _, path := range []string{cardA, cardB} {
+		if content := fileContent(path); !strings.HasPrefix(content, "---\ntags: [go]\n---\n") {
+			t.Errorf("Expected the front matter to be as it was, got:\n%s", content)
+		}
+	}
+
+	if err := store.SuspendCard("missing.md"); err == nil {
+		t.Error("Expected an error for a card that does not exist")
+	}
+}
@@ -0,0 +1,252 @@
+// File: internal/data/tag_filter.go
+
+package data
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+// TagFilter selects cards by their tags with an expression such as
+// "go AND concurrency AND NOT basics". Tags are combined with NOT, AND and
+// OR, from the tightest binding to the loosest, and grouped with
+// parentheses. Tags written next to each other must all be present. A tag
+// with spaces, or named like an operator, is written in double quotes. Tags
+// and operators are matched regardless of case.
+type TagFilter struct {
+	expr tagExpr
+	text string
+}
+
+// tagExpr is a node of a parsed tag filter
+type tagExpr interface {
+	match(tags []string) bool
+}
+
+type (
+	tagTerm string                 // Cards with the tag
+	tagNot  struct{ expr tagExpr } // Cards the expression does not match
+	tagAnd  struct{ left, right tagExpr }
+	tagOr   struct{ left, right tagExpr }
+)
+
+func (t tagTerm) match(tags []string) bool {
+	for _, tag := range tags {
+		if strings.EqualFold(tag, string(t)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n tagNot) match(tags []string) bool { return !n.expr.match(tags) }
+func (a tagAnd) match(tags []string) bool { return a.left.match(tags) && a.right.match(tags) }
+func (o tagOr) match(tags []string) bool  { return o.left.match(tags) || o.right.match(tags) }
+
+// ParseTagFilter parses a tag filter expression
+func ParseTagFilter(text string) (TagFilter, error) {
+	tokens, err := tokenizeTagFilter(text)
+	if err != nil {
+		return TagFilter{}, err
+	}
+	if len(tokens) == 0 {
+		return TagFilter{}, fmt.Errorf("empty tag filter")
+	}
+
+	p := &tagParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return TagFilter{}, err
+	}
+	if !p.done() {
+		return TagFilter{}, fmt.Errorf("unexpected %q in tag filter", p.peek().text)
+	}
+
+	return TagFilter{expr: expr, text: strings.TrimSpace(text)}, nil
+}
+
+// Match reports whether a card's tags satisfy the filter
+func (f TagFilter) Match(card model.Card) bool {
+	return f.expr != nil && f.expr.match(card.Tags)
+}
+
+// String returns the expression the filter was parsed from
+func (f TagFilter) String() string {
+	return f.text
+}
+
+// tagToken is a word of a tag filter: a tag, an operator or a parenthesis
+type tagToken struct {
+	text   string
+	quoted bool // A tag written in double quotes, never an operator
+}
+
+// is reports whether the token is the given operator or parenthesis
+func (t tagToken) is(op string) bool {
+	return !t.quoted && strings.EqualFold(t.text, op)
+}
+
+// isOperator reports whether the token is an operator or parenthesis
+func (t tagToken) isOperator() bool {
+	return t.is("AND") || t.is("OR") || t.is("NOT") || t.is("(") || t.is(")")
+}
+
+// tokenizeTagFilter splits a tag filter into tags, operators and parentheses
+func tokenizeTagFilter(text string) ([]tagToken, error) {
+	var tokens []tagToken
+	runes := []rune(text)
+
+	for i := 0; i < len(runes); {
+		switch r := runes[i]; {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '(' || r == ')':
+			tokens = append(tokens, tagToken{text: string(r)})
+			i++
+
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unclosed quote in tag filter")
+			}
+			tokens = append(tokens, tagToken{text: string(runes[i+1 : end]), quoted: true})
+			i = end + 1
+
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune(`()"`, runes[end]) {
+				end++
+			}
+			tokens = append(tokens, tagToken{text: string(runes[i:end])})
+			i = end
+		}
+	}
+
+	return tokens, nil
+}
+
+// tagParser parses tag filter tokens by recursive descent
+type tagParser struct {
+	tokens []tagToken
+	pos    int
+}
+
+func (p *tagParser) done() bool     { return p.pos >= len(p.tokens) }
+func (p *tagParser) peek() tagToken { return p.tokens[p.pos] }
+
+// accept moves past the next token if it is the given operator
+func (p *tagParser) accept(op string) bool {
+	if !p.done() && p.peek().is(op) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// parseOr parses expressions joined by OR
+func (p *tagParser) parseOr() (tagExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = tagOr{left, right}
+	}
+	return left, nil
+}
+
+// parseAnd parses expressions joined by AND or written next to each other
+func (p *tagParser) parseAnd() (tagExpr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for !p.done() && !p.peek().is("OR") && !p.peek().is(")") {
+		p.accept("AND")
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = tagAnd{left, right}
+	}
+	return left, nil
+}
+
+// parseNot parses a tag or parenthesized expression, possibly negated
+func (p *tagParser) parseNot() (tagExpr, error) {
+	if p.accept("NOT") {
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return tagNot{expr}, nil
+	}
+
+	if p.done() {
+		return nil, fmt.Errorf("tag filter ends where a tag is expected")
+	}
+
+	if p.accept("(") {
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, fmt.Errorf("missing ) in tag filter")
+		}
+		return expr, nil
+	}
+
+	token := p.peek()
+	if token.isOperator() {
+		return nil, fmt.Errorf("unexpected %q in tag filter, expected a tag", token.text)
+	}
+	p.pos++
+	return tagTerm(token.text), nil
+}
+
+// Tags returns every tag used in the collection, in alphabetical order
+func (s *Store) Tags() []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, deck := range s.Decks {
+		for _, card := range deck.Cards {
+			for _, tag := range card.Tags {
+				if !seen[strings.ToLower(tag)] {
+					seen[strings.ToLower(tag)] = true
+					tags = append(tags, tag)
+				}
+			}
+		}
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return strings.ToLower(tags[i]) < strings.ToLower(tags[j])
+	})
+	return tags
+}
+
+// TaggedCards returns the cards of every deck matching a tag filter
+func (s *Store) TaggedCards(filter TagFilter) []model.Card {
+	var cards []model.Card
+	for _, deck := range s.Decks {
+		for _, card := range deck.Cards {
+			if filter.Match(card) {
+				cards = append(cards, card)
+			}
+		}
+	}
+	return cards
+}
@@ -0,0 +1,113 @@
+// File: internal/data/tag_filter_test.go
+
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DavidMiserak/GoCard/internal/clock"
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+func TestTagFilterMatch(t *testing.T) {
+	tests := []struct {
+		filter string
+		tags   []string
+		match  bool
+	}{
+		{"go", []string{"go", "basics"}, true},
+		{"go", []string{"rust"}, false},
+		{"GO", []string{"go"}, true},
+		{"go AND concurrency AND NOT basics", []string{"go", "concurrency"}, true},
+		{"go AND concurrency AND NOT basics", []string{"go", "concurrency", "basics"}, false},
+		{"go concurrency", []string{"go"}, false},
+		{"go concurrency", []string{"concurrency", "go"}, true},
+		{"go OR rust", []string{"rust"}, true},
+		{"go and not rust", []string{"go"}, true},
+		{"go OR rust AND unsafe", []string{"go"}, true},
+		{"(go OR rust) AND unsafe", []string{"go"}, false},
+		{"NOT (go OR rust)", []string{"python"}, true},
+		{"NOT NOT go", []string{"go"}, true},
+		{`"data structures"`, []string{"Data Structures"}, true},
+		{`"and"`, []string{"and"}, true},
+		{"go", nil, false},
+	}
+
+	for _, test := range tests {
+		filter, err := ParseTagFilter(test.filter)
+		if err != nil {
+			t.Errorf("Expected %q to parse, got error: %v", test.filter, err)
+			continue
+		}
+		if got := filter.Match(model.Card{Tags: test.tags}); got != test.match {
+			t.Errorf("Expected %q matching tags %v to be %v, got %v", test.filter, test.tags, test.match, got)
+		}
+	}
+}
+
+func TestParseTagFilterErrors(t *testing.T) {
+	for _, text := range []string{
+		"",
+		"   ",
+		"go AND",
+		"OR go",
+		"NOT",
+		"(go OR rust",
+		"go)",
+		"()",
+		`"unclosed`,
+		"go AND AND rust",
+	} {
+		if _, err := ParseTagFilter(text); err == nil {
+			t.Errorf("Expected an error parsing %q", text)
+		}
+	}
+
+	// The zero filter matches nothing
+	if (TagFilter{}).Match(model.Card{Tags: []string{"go"}}) {
+		t.Errorf("Expected the zero filter to match no card")
+	}
+}
+
+func TestTagStudyQueue(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	due := func(id, deckID string, tags ...string) model.Card {
+		return model.Card{ID: id, DeckID: deckID, Tags: tags, LastReviewed: now.AddDate(0, 0, -3), NextReview: now.Add(-time.Hour)}
+	}
+
+	store := &Store{
+		Config: DefaultConfig(),
+		Decks: []model.Deck{
+			{ID: "go", Cards: []model.Card{
+				due("goroutines", "go", "go", "concurrency"),
+				due("hello", "go", "go", "basics"),
+				{ID: "channels", DeckID: "go", Tags: []string{"go", "Concurrency"}, LastReviewed: now, NextReview: now.AddDate(0, 0, 4)},
+			}},
+			{ID: "rust", Cards: []model.Card{due("threads", "rust", "rust", "concurrency")}},
+			{ID: "spanish", Cards: []model.Card{due("hola", "spanish")}},
+		},
+	}
+	store.SetClock(clock.Fixed(now))
+
+	filter, err := ParseTagFilter("concurrency AND NOT basics")
+	if err != nil {
+		t.Fatalf("Failed to parse tag filter: %v", err)
+	}
+
+	if got := cardIDs(store.TaggedCards(filter)); !equalIDs(got, []string{"goroutines", "channels", "threads"}) {
+		t.Errorf("Expected tagged cards [goroutines channels threads], got %v", got)
+	}
+	if got := cardIDs(store.TagStudyQueue(filter)); len(got) != 2 || !containsString(got, "goroutines") || !containsString(got, "threads") {
+		t.Errorf("Expected to study goroutines and threads, got %v", got)
+	}
+	if got := cardIDs(store.TagStudyAheadCards(filter)); !equalIDs(got, []string{"channels"}) {
+		t.Errorf("Expected to study ahead [channels], got %v", got)
+	}
+
+	// Tags are listed once, whatever their case
+	expectedTags := []string{"basics", "concurrency", "go", "rust"}
+	if got := store.Tags(); !equalIDs(got, expectedTags) {
+		t.Errorf("Expected tags %v, got %v", expectedTags, got)
+	}
+}
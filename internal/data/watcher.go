@@ -0,0 +1,254 @@
+// File: internal/data/watcher.go
+
+package data
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultPollInterval is how often a directory is scanned for changes when
+// the system cannot notify about them
+const DefaultPollInterval = 2 * time.Second
+
+// watchDebounce is how long to wait for more changes before reporting them,
+// as editors often save a file in several steps
+const watchDebounce = 100 * time.Millisecond
+
+// Watcher reports the markdown files of a collection that are changed,
+// added, deleted or renamed on disk
+type Watcher struct {
+	Changes <-chan []string // Paths of the files that changed, in batches
+
+	changes   chan []string
+	done      chan struct{}
+	closeOnce sync.Once
+	notify    *fsnotify.Watcher // Nil when polling
+}
+
+// WatchDir watches the markdown files in a directory and its
+// subdirectories. When the system cannot notify about changes, for example
+// because it is out of watches, the directory is polled instead.
+func WatchDir(dirPath string) (*Watcher, error) {
+	if _, err := os.Stat(dirPath); err != nil {
+		return nil, fmt.Errorf("error watching directory: %w", err)
+	}
+
+	notify, err := fsnotify.NewWatcher()
+	if err != nil {
+		return PollDir(dirPath, DefaultPollInterval), nil
+	}
+	if err := addWatches(notify, dirPath); err != nil {
+		notify.Close() //nolint:errcheck
+		return PollDir(dirPath, DefaultPollInterval), nil
+	}
+
+	w := newWatcher()
+	w.notify = notify
+	go w.watch()
+	return w, nil
+}
+
+// PollDir watches the markdown files in a directory and its subdirectories
+// by scanning them at the given interval
+func PollDir(dirPath string, interval time.Duration) *Watcher {
+	w := newWatcher()
+	go w.poll(dirPath, scanMarkdownFiles(dirPath), interval)
+	return w
+}
+
+// newWatcher creates a watcher that is not watching anything yet
+func newWatcher() *Watcher {
+	changes := make(chan []string)
+	return &Watcher{
+		Changes: changes,
+		changes: changes,
+		done:    make(chan struct{}),
+	}
+}
+
+// Close stops watching. Changes is closed once the watcher has stopped.
+func (w *Watcher) Close() error {
+	var err error
+	w.closeOnce.Do(func() {
+		close(w.done)
+		if w.notify != nil {
+			err = w.notify.Close()
+		}
+	})
+	return err
+}
+
+// watch reports the markdown files the system notifies about, once no more
+// changes came for a short while
+func (w *Watcher) watch() {
+	defer close(w.changes)
+
+	pending := make(map[string]bool)
+	var flush <-chan time.Time
+
+	for {
+		select {
+		case <-w.done:
+			return
+
+		case event, ok := <-w.notify.Events:
+			if !ok {
+				return
+			}
+			if event.Op == fsnotify.Chmod {
+				continue // The content did not change
+			}
+
+			// Watch new directories too, with the files moved in with them
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					addWatches(w.notify, event.Name) //nolint:errcheck // Best effort
+					for path := range scanMarkdownFiles(event.Name) {
+						pending[path] = true
+					}
+				}
+			}
+
+			if isMarkdownFile(event.Name) {
+				pending[event.Name] = true
+			}
+			if len(pending) > 0 {
+				flush = time.After(watchDebounce)
+			}
+
+		case _, ok := <-w.notify.Errors:
+			if !ok {
+				return
+			}
+			// Changes lost, for example when too many happened at once, are
+			// picked up the next time the files change
+
+		case <-flush:
+			flush = nil
+			if !w.send(sortedPaths(pending)) {
+				return
+			}
+			pending = make(map[string]bool)
+		}
+	}
+}
+
+// poll reports the markdown files whose size or modification time changed
+// since the previous scan, and those added or removed
+func (w *Watcher) poll(dirPath string, known map[string]fileState, interval time.Duration) {
+	defer close(w.changes)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return
+
+		case <-ticker.C:
+			current := scanMarkdownFiles(dirPath)
+			changed := make(map[string]bool)
+			for path, state := range current {
+				if known[path] != state {
+					changed[path] = true
+				}
+			}
+			for path := range known {
+				if _, ok := current[path]; !ok {
+					changed[path] = true
+				}
+			}
+			known = current
+
+			if len(changed) > 0 && !w.send(sortedPaths(changed)) {
+				return
+			}
+		}
+	}
+}
+
+// send reports changed files, returning false if the watcher was closed
+// before they could be reported
+func (w *Watcher) send(paths []string) bool {
+	select {
+	case w.changes <- paths:
+		return true
+	case <-w.done:
+		return false
+	}
+}
+
+// fileState is what polling compares to tell whether a file changed
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// scanMarkdownFiles returns the state of every markdown file in a directory
+// and its subdirectories. Files that cannot be read are left out.
+func scanMarkdownFiles(dirPath string) map[string]fileState {
+	files := make(map[string]fileState)
+	filepath.WalkDir(dirPath, func(path string, d fs.DirEntry, err error) error { //nolint:errcheck
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() && path != dirPath && isHidden(path) {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || !isMarkdownFile(path) {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			files[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+		}
+		return nil
+	})
+	return files
+}
+
+// addWatches watches a directory and its subdirectories, leaving out hidden
+// ones such as .git as the collection does not load them
+func addWatches(notify *fsnotify.Watcher, dirPath string) error {
+	return filepath.WalkDir(dirPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != dirPath && isHidden(path) {
+			return filepath.SkipDir
+		}
+		return notify.Add(path)
+	})
+}
+
+// isMarkdownFile reports whether a path names a card file
+func isMarkdownFile(path string) bool {
+	return strings.HasSuffix(strings.ToLower(path), ".md")
+}
+
+// isHidden reports whether a file or directory is hidden
+func isHidden(path string) bool {
+	return strings.HasPrefix(filepath.Base(path), ".")
+}
+
+// sortedPaths returns the paths of a set in order
+func sortedPaths(set map[string]bool) []string {
+	paths := make([]string, 0, len(set))
+	for path := range set {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
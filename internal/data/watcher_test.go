@@ -0,0 +1,90 @@
+// File: internal/data/watcher_test.go
+
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// nextChanges waits for the next batch of changes reported by a watcher
+func nextChanges(t *testing.T, w *Watcher) []string {
+	t.Helper()
+	select {
+	case paths := <-w.Changes:
+		return paths
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for changes")
+		return nil
+	}
+}
+
+// testWatcher checks that a watcher reports the markdown files that are
+// changed, added, removed and renamed in a collection
+func testWatcher(t *testing.T, watch func(dir string) (*Watcher, error)) {
+	tempDir := t.TempDir()
+	deckDir := filepath.Join(tempDir, "go")
+	if err := os.Mkdir(deckDir, 0755); err != nil {
+		t.Fatalf("Failed to create deck dir: %v", err)
+	}
+	cardPath := filepath.Join(deckDir, "card.md")
+	if err := os.WriteFile(cardPath, []byte("first"), 0644); err != nil {
+		t.Fatalf("Failed to write card: %v", err)
+	}
+
+	w, err := watch(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to watch: %v", err)
+	}
+	defer w.Close() //nolint:errcheck
+
+	// Editing a card, next to a file that is not a card
+	if err := os.WriteFile(filepath.Join(deckDir, "notes.txt"), []byte("notes"), 0644); err != nil {
+		t.Fatalf("Failed to write notes: %v", err)
+	}
+	if err := os.WriteFile(cardPath, []byte("second version"), 0644); err != nil {
+		t.Fatalf("Failed to write card: %v", err)
+	}
+	if got := nextChanges(t, w); !reflect.DeepEqual(got, []string{cardPath}) {
+		t.Errorf("Expected the edited card, got %v", got)
+	}
+
+	// Renaming reports both names
+	renamedPath := filepath.Join(deckDir, "renamed.md")
+	if err := os.Rename(cardPath, renamedPath); err != nil {
+		t.Fatalf("Failed to rename card: %v", err)
+	}
+	if got := nextChanges(t, w); !reflect.DeepEqual(got, []string{cardPath, renamedPath}) {
+		t.Errorf("Expected the old and new names, got %v", got)
+	}
+
+	// Closing the watcher closes its changes
+	if err := w.Close(); err != nil {
+		t.Errorf("Close error: %v", err)
+	}
+	select {
+	case _, ok := <-w.Changes:
+		if ok {
+			t.Error("Expected no more changes after closing")
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("Timed out waiting for changes to close")
+	}
+}
+
+func TestWatchDir(t *testing.T) {
+	testWatcher(t, WatchDir)
+
+	if _, err := WatchDir(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Expected an error for a missing directory")
+	}
+}
+
+func TestPollDir(t *testing.T) {
+	testWatcher(t, func(dir string) (*Watcher, error) {
+		return PollDir(dir, 20*time.Millisecond), nil
+	})
+}
@@ -2,7 +2,10 @@
 
 package model
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // TODO: Make a tool to import Markdown files in directory to cards
 
@@ -15,6 +18,70 @@ type Card struct {
 	LastReviewed time.Time
 	NextReview   time.Time
 	Ease         float64
-	Interval     int // in days
-	Rating       int // 1-5 rating per SmartMemo2 Algorithm
+	Interval     int     // in days
+	Rating       int     // 1-5 rating per SmartMemo2 Algorithm
+	Stability    float64 // FSRS memory stability in days, 0 if not scheduled by FSRS
+	Difficulty   float64 // FSRS difficulty from 1 (easy) to 10 (hard)
+	State        CardState
+	Step         int       // Index of the current learning or relearning step
+	Lapses       int       // Times the card was forgotten after it was learned
+	Tags         []string  // Tags from the card's front matter
+	Created      time.Time // When the card was written, zero if unknown
+	Suspended    bool      // Left out of study sessions until unsuspended
+	BuriedUntil  time.Time // Left out of study sessions until this time, zero if not buried
+}
+
+// LeechTag marks cards that were forgotten so often they need rewriting
+const LeechTag = "leech"
+
+// HasTag reports whether the card has the given tag
+func (c Card) HasTag(tag string) bool {
+	for _, t := range c.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// IsLeech reports whether the card is tagged as a leech
+func (c Card) IsLeech() bool {
+	return c.HasTag(LeechTag)
+}
+
+// CardState is the stage of learning a card is in
+type CardState int
+
+const (
+	StateNew        CardState = iota // Never reviewed
+	StateLearning                    // Going through the learning steps for the first time
+	StateReview                      // Reviewed at intervals of days
+	StateRelearning                  // Forgotten, going through the relearning steps
+)
+
+// cardStateNames are the names of the states as written in card files
+var cardStateNames = []string{"new", "learning", "review", "relearning"}
+
+// String returns the name of the state
+func (s CardState) String() string {
+	if s < 0 || int(s) >= len(cardStateNames) {
+		return fmt.Sprintf("CardState(%d)", int(s))
+	}
+	return cardStateNames[s]
+}
+
+// ParseCardState returns the state with the given name
+func ParseCardState(name string) (CardState, error) {
+	for i, stateName := range cardStateNames {
+		if name == stateName {
+			return CardState(i), nil
+		}
+	}
+	return StateNew, fmt.Errorf("unknown card state %q", name)
+}
+
+// IsLearning reports whether the card is going through learning or
+// relearning steps, which are measured in minutes rather than days
+func (c Card) IsLearning() bool {
+	return c.State == StateLearning || c.State == StateRelearning
 }
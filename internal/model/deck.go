@@ -8,6 +8,7 @@ import "time"
 type Deck struct {
 	ID          string // Will be filepath of the deck (directory)
 	Name        string // Will be base name of the directory
+	ParentID    string // ID of the deck this is a sub-deck of, empty for top-level decks
 	Description string
 	Cards       []Card // TODO: Make a tool to import Markdown files in directory to cards
 	CreatedAt   time.Time
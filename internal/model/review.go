@@ -0,0 +1,18 @@
+// File: internal/model/review.go
+
+package model
+
+import "time"
+
+// ReviewEvent records a single review of a card
+type ReviewEvent struct {
+	CardID       string // Filepath of the reviewed card
+	DeckID       string // Filepath of the deck (directory) the card belongs to
+	Timestamp    time.Time
+	Rating       int // 1-5 rating given by the user
+	PrevInterval int // Interval before the review, in days
+	NewInterval  int // Interval after the review, in days
+	PrevEase     float64
+	NewEase      float64
+	TimeToAnswer time.Duration // Time between showing the question and rating the card
+}
@@ -5,6 +5,7 @@ package srs
 import (
 	"time"
 
+	"github.com/DavidMiserak/GoCard/internal/clock"
 	"github.com/DavidMiserak/GoCard/internal/model"
 )
 
@@ -16,6 +17,13 @@ const (
 	maxInterval     = 365  // Maximum interval in days
 	easyBonus       = 1.3  // Multiplier for "easy" cards
 	defaultInterval = 1    // Default interval for new cards
+
+	// Share of the days a review was overdue that count towards the next
+	// interval: a late review rated hard may have been recalled only
+	// because of the recent study, an easy one shows the card held up
+	hardLateCredit = 0.25
+	goodLateCredit = 0.5
+	easyLateCredit = 1.0
 )
 
 // ScheduleCard updates a card based on the user's rating (1-5)
@@ -28,8 +36,25 @@ const (
 // 4 - Good (correct with some effort)
 // 5 - Easy (correct with no effort)
 func ScheduleCard(card model.Card, rating int) model.Card {
+	return ScheduleCardAt(card, rating, time.Now())
+}
+
+// ScheduleCardAt updates a card as if it was reviewed at the given time.
+// Successful reviews grow the interval from the days that actually passed
+// since the last review, so cards reviewed late get credit for the longer
+// wait and cards studied ahead do not get the full increase.
+func ScheduleCardAt(card model.Card, rating int, now time.Time) model.Card {
+	return scheduleCardAt(card, rating, now, 0)
+}
+
+// scheduleCardAt updates a card as if it was reviewed at the given time,
+// counting the days since the last review from days that start at
+// dayStartHour (0-23)
+func scheduleCardAt(card model.Card, rating int, now time.Time, dayStartHour int) model.Card {
+	elapsed := elapsedDays(card, now, dayStartHour)
+
 	// Update the last reviewed time
-	card.LastReviewed = time.Now()
+	card.LastReviewed = now
 
 	// Store the user's rating
 	card.Rating = rating
@@ -51,31 +76,31 @@ func ScheduleCard(card model.Card, rating int) model.Card {
 		if card.Interval == 0 {
 			card.Interval = 1
 		} else {
-			card.Interval = int(float64(card.Interval) * 1.2)
+			card.Interval = grownInterval(card.Interval, elapsed, hardLateCredit, 1.2)
 		}
 		card.Ease = maxFloat(card.Ease-easeModifier, minEase)
 
 	case 4: // Good
 		// Standard increase in interval
-		switch card.Interval {
-		case 0:
+		switch {
+		case card.Interval == 0:
 			card.Interval = defaultInterval
-		case 1:
-			card.Interval = 3
+		case card.Interval == 1 && elapsed >= 1:
+			card.Interval = maxInt(3, grownInterval(card.Interval, elapsed, goodLateCredit, card.Ease))
 		default:
-			card.Interval = int(float64(card.Interval) * card.Ease)
+			card.Interval = grownInterval(card.Interval, elapsed, goodLateCredit, card.Ease)
 		}
 		// Ease remains the same
 
 	case 5: // Easy
 		// Larger increase in interval, increase ease
-		switch card.Interval {
-		case 0:
+		switch {
+		case card.Interval == 0:
 			card.Interval = defaultInterval * 2
-		case 1:
-			card.Interval = 4
+		case card.Interval == 1 && elapsed >= 1:
+			card.Interval = maxInt(4, grownInterval(card.Interval, elapsed, easyLateCredit, card.Ease*easyBonus))
 		default:
-			card.Interval = int(float64(card.Interval) * card.Ease * easyBonus)
+			card.Interval = grownInterval(card.Interval, elapsed, easyLateCredit, card.Ease*easyBonus)
 		}
 		card.Ease = minFloat(card.Ease+easeModifier, 4.0)
 	}
@@ -84,19 +109,52 @@ func ScheduleCard(card model.Card, rating int) model.Card {
 	card.Interval = minInt(card.Interval, maxInterval)
 
 	// Set the next review date
-	card.NextReview = time.Now().AddDate(0, 0, card.Interval)
+	card.NextReview = now.AddDate(0, 0, card.Interval)
 
 	return card
 }
 
+// elapsedDays returns the number of days from the last review of a card to
+// now, counted in days starting at dayStartHour so that any time on the day
+// a card is due is on time. Cards without a last review are taken to be on
+// time.
+func elapsedDays(card model.Card, now time.Time, dayStartHour int) int {
+	if card.LastReviewed.IsZero() || now.Before(card.LastReviewed) {
+		return card.Interval
+	}
+
+	lastDay := clock.StartOfDay(card.LastReviewed, now.Location(), dayStartHour)
+	return clock.DaysBetween(lastDay, clock.StartOfDay(now, now.Location(), dayStartHour))
+}
+
+// grownInterval returns the interval after a successful review that came
+// elapsed days after the previous one, multiplied by factor. A late review
+// is credited with the given share of the days it was overdue. An early
+// review grows from the days that passed, but never shortens the interval.
+func grownInterval(interval, elapsed int, lateCredit, factor float64) int {
+	if elapsed < interval {
+		return maxInt(int(float64(elapsed)*factor), interval)
+	}
+
+	overdue := float64(elapsed - interval)
+	return int((float64(interval) + lateCredit*overdue) * factor)
+}
+
 // InitializeNewCard initializes a new card with default SRS values
 func InitializeNewCard(card model.Card) model.Card {
+	return InitializeNewCardAt(card, time.Now())
+}
+
+// InitializeNewCardAt initializes a new card that is due at the given time
+func InitializeNewCardAt(card model.Card, now time.Time) model.Card {
 	// Set default values for a new card
 	if card.Ease == 0 {
 		card.Ease = defaultEase
 	}
 	card.Interval = 0
-	card.NextReview = time.Now() // Due immediately
+	card.NextReview = now // Due immediately
+	card.State = model.StateNew
+	card.Step = 0
 
 	return card
 }
@@ -109,6 +167,13 @@ func minInt(a, b int) int {
 	return b
 }
 
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 func maxFloat(a, b float64) float64 {
 	if a > b {
 		return a
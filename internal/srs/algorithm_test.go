@@ -0,0 +1,109 @@
+// File: internal/srs/algorithm_test.go
+
+package srs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+func TestScheduleCardElapsedDays(t *testing.T) {
+	due := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+
+	// A card with a 10 day interval, due on 2025-03-31
+	card := model.Card{
+		LastReviewed: due.AddDate(0, 0, -10),
+		NextReview:   due,
+		Interval:     10,
+		Ease:         2.5,
+		State:        model.StateReview,
+	}
+
+	testCases := []struct {
+		name     string
+		reviewed time.Time
+		rating   int
+		expected int
+	}{
+		// On time, the interval grows as usual
+		{"on time hard", due, 3, 12},
+		{"on time good", due, 4, 25},
+		{"on time easy", due, 5, 32},
+		{"on time later in the day", due.Add(12 * time.Hour), 4, 25},
+
+		// 60 days overdue, part of the extra days count for hard and good
+		// and all of them for easy
+		{"overdue hard", due.AddDate(0, 0, 60), 3, 30},
+		{"overdue good", due.AddDate(0, 0, 60), 4, 100},
+		{"overdue easy", due.AddDate(0, 0, 60), 5, 227},
+
+		// Studied ahead 4 days after the last review, the interval grows
+		// from those 4 days but does not shrink
+		{"early hard", due.AddDate(0, 0, -6), 3, 10},
+		{"early good", due.AddDate(0, 0, -6), 4, 10},
+		{"early easy", due.AddDate(0, 0, -6), 5, 13},
+
+		// Failing resets the interval however late or early
+		{"overdue wrong", due.AddDate(0, 0, 60), 2, 1},
+		{"early blackout", due.AddDate(0, 0, -6), 1, 1},
+	}
+
+	for _, tc := range testCases {
+		scheduled := ScheduleCardAt(card, tc.rating, tc.reviewed)
+		if scheduled.Interval != tc.expected {
+			t.Errorf("%s: expected interval %d, got %d", tc.name, tc.expected, scheduled.Interval)
+		}
+		if !scheduled.NextReview.Equal(tc.reviewed.AddDate(0, 0, scheduled.Interval)) {
+			t.Errorf("%s: expected next review %d days after %v, got %v",
+				tc.name, scheduled.Interval, tc.reviewed, scheduled.NextReview)
+		}
+	}
+}
+
+func TestScheduleCardFirstIntervals(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	card := model.Card{LastReviewed: now.AddDate(0, 0, -1), Interval: 1, Ease: 2.5}
+
+	// A 1 day interval reviewed on time moves to the usual next steps
+	if got := ScheduleCardAt(card, 4, now).Interval; got != 3 {
+		t.Errorf("Expected good to give 3 days, got %d", got)
+	}
+	if got := ScheduleCardAt(card, 5, now).Interval; got != 4 {
+		t.Errorf("Expected easy to give 4 days, got %d", got)
+	}
+
+	// Reviewed again the same day, it does not move ahead
+	if got := ScheduleCardAt(card, 4, card.LastReviewed.Add(time.Hour)).Interval; got != 1 {
+		t.Errorf("Expected good on the same day to keep 1 day, got %d", got)
+	}
+
+	// Overdue by a month, it gets credit for the wait
+	if got := ScheduleCardAt(card, 4, now.AddDate(0, 0, 30)).Interval; got != 40 {
+		t.Errorf("Expected good a month late to give 40 days, got %d", got)
+	}
+
+	// New cards have no elapsed time to go by
+	newCard := InitializeNewCardAt(model.Card{}, now)
+	if got := ScheduleCardAt(newCard, 4, now.AddDate(0, 0, 5)).Interval; got != 1 {
+		t.Errorf("Expected a new card to get 1 day, got %d", got)
+	}
+}
+
+func TestScheduleCardDayStartHour(t *testing.T) {
+	// Reviewed at 23:00 and again at 1:00 the next night
+	lastReviewed := time.Date(2025, 3, 30, 23, 0, 0, 0, time.UTC)
+	now := lastReviewed.Add(2 * time.Hour)
+	card := model.Card{LastReviewed: lastReviewed, Interval: 1, Ease: 2.5, State: model.StateReview}
+
+	// With days starting at midnight, a day has passed
+	if got := (SM2Scheduler{}).Schedule(card, 4, now).Interval; got != 3 {
+		t.Errorf("Expected good a day later to give 3 days, got %d", got)
+	}
+
+	// With days starting at 4:00, both reviews are on the same day
+	if got := (SM2Scheduler{DayStartHour: 4}).Schedule(card, 4, now).Interval; got != 1 {
+		t.Errorf("Expected good on the same day to keep 1 day, got %d", got)
+	}
+}
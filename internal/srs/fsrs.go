@@ -0,0 +1,180 @@
+// File: internal/srs/fsrs.go
+
+package srs
+
+import (
+	"math"
+	"time"
+
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+// FSRS grades
+const (
+	fsrsAgain = 1
+	fsrsHard  = 2
+	fsrsGood  = 3
+	fsrsEasy  = 4
+)
+
+// Default values for the FSRS algorithm
+const (
+	DefaultDesiredRetention = 0.9 // Probability of recall targeted when scheduling
+	fsrsDecay               = -0.5
+	fsrsFactor              = 19.0 / 81.0 // Chosen so that R(S, S) = 0.9
+	fsrsMinDifficulty       = 1.0
+	fsrsMaxDifficulty       = 10.0
+	fsrsMinStability        = 0.1
+)
+
+// fsrsWeights are the default FSRS-4.5 model parameters
+var fsrsWeights = [17]float64{
+	0.4872, 1.4003, 3.7145, 13.8206, 5.1618, 1.2298, 0.8975, 0.031,
+	1.6474, 0.1367, 1.0461, 2.1072, 0.0793, 0.3246, 1.587, 0.2272, 2.8755,
+}
+
+// ScheduleCardFSRS updates a card reviewed at the given time using the Free
+// Spaced Repetition Scheduler and returns the updated card. desiredRetention is the probability of recall
+// the next review should be scheduled at; values outside (0, 1) fall back to
+// DefaultDesiredRetention.
+//
+// The 1-5 rating scale is mapped onto the FSRS grades:
+// 1, 2 - Again
+// 3    - Hard
+// 4    - Good
+// 5    - Easy
+func ScheduleCardFSRS(card model.Card, rating int, desiredRetention float64, now time.Time) model.Card {
+	if desiredRetention <= 0 || desiredRetention >= 1 {
+		desiredRetention = DefaultDesiredRetention
+	}
+
+	grade := fsrsGrade(rating)
+
+	// A card previously scheduled by SM-2 has no memory state yet. Its
+	// interval approximates the stability, since both describe when recall
+	// drops to about 90%.
+	if card.Stability <= 0 && card.Interval > 0 && !card.LastReviewed.IsZero() {
+		card.Stability = float64(card.Interval)
+		card.Difficulty = fsrsInitDifficulty(fsrsGood)
+	}
+
+	if card.Stability > 0 {
+		// Regular review of a card with a memory state
+		retrievability := Retrievability(card, now)
+		card.Difficulty = fsrsNextDifficulty(card.Difficulty, grade)
+		if grade == fsrsAgain {
+			card.Stability = fsrsForgetStability(card.Difficulty, card.Stability, retrievability)
+		} else {
+			card.Stability = fsrsRecallStability(card.Difficulty, card.Stability, retrievability, grade)
+		}
+	} else {
+		// First review of a new card
+		card.Stability = fsrsInitStability(grade)
+		card.Difficulty = fsrsInitDifficulty(grade)
+	}
+
+	card.LastReviewed = now
+	card.Rating = rating
+	card.Interval = fsrsInterval(card.Stability, desiredRetention)
+	card.NextReview = now.AddDate(0, 0, card.Interval)
+
+	return card
+}
+
+// Retrievability returns the estimated probability that the card is recalled
+// at the given time, based on its FSRS stability. Cards without a memory
+// state have a retrievability of 0.
+func Retrievability(card model.Card, at time.Time) float64 {
+	if card.Stability <= 0 || card.LastReviewed.IsZero() {
+		return 0
+	}
+
+	elapsedDays := at.Sub(card.LastReviewed).Hours() / 24
+	if elapsedDays < 0 {
+		elapsedDays = 0
+	}
+
+	return math.Pow(1+fsrsFactor*elapsedDays/card.Stability, fsrsDecay)
+}
+
+// fsrsGrade maps the 1-5 rating scale onto the FSRS grades
+func fsrsGrade(rating int) int {
+	switch {
+	case rating <= 2:
+		return fsrsAgain
+	case rating == 3:
+		return fsrsHard
+	case rating == 4:
+		return fsrsGood
+	default:
+		return fsrsEasy
+	}
+}
+
+// fsrsInitStability returns the stability after the first review
+func fsrsInitStability(grade int) float64 {
+	return math.Max(fsrsWeights[grade-1], fsrsMinStability)
+}
+
+// fsrsInitDifficulty returns the difficulty after the first review
+func fsrsInitDifficulty(grade int) float64 {
+	return clampDifficulty(fsrsWeights[4] - float64(grade-3)*fsrsWeights[5])
+}
+
+// fsrsNextDifficulty returns the difficulty after a review, reverting
+// towards the initial difficulty to avoid "ease hell"
+func fsrsNextDifficulty(difficulty float64, grade int) float64 {
+	next := difficulty - fsrsWeights[6]*float64(grade-3)
+	reverted := fsrsWeights[7]*fsrsWeights[4] + (1-fsrsWeights[7])*next
+	return clampDifficulty(reverted)
+}
+
+// fsrsRecallStability returns the stability after a successful review
+func fsrsRecallStability(difficulty, stability, retrievability float64, grade int) float64 {
+	hardPenalty := 1.0
+	if grade == fsrsHard {
+		hardPenalty = fsrsWeights[15]
+	}
+
+	easyBonus := 1.0
+	if grade == fsrsEasy {
+		easyBonus = fsrsWeights[16]
+	}
+
+	growth := math.Exp(fsrsWeights[8]) *
+		(11 - difficulty) *
+		math.Pow(stability, -fsrsWeights[9]) *
+		(math.Exp((1-retrievability)*fsrsWeights[10]) - 1) *
+		hardPenalty *
+		easyBonus
+
+	return stability * (1 + growth)
+}
+
+// fsrsForgetStability returns the stability after a failed review
+func fsrsForgetStability(difficulty, stability, retrievability float64) float64 {
+	next := fsrsWeights[11] *
+		math.Pow(difficulty, -fsrsWeights[12]) *
+		(math.Pow(stability+1, fsrsWeights[13]) - 1) *
+		math.Exp((1-retrievability)*fsrsWeights[14])
+
+	// Forgetting never makes the memory more stable than it was
+	return math.Max(math.Min(next, stability), fsrsMinStability)
+}
+
+// fsrsInterval returns the interval in days after which the retrievability
+// drops to the desired retention
+func fsrsInterval(stability, desiredRetention float64) int {
+	interval := stability / fsrsFactor * (math.Pow(desiredRetention, 1/fsrsDecay) - 1)
+	days := int(math.Round(interval))
+
+	if days < 1 {
+		days = 1
+	}
+
+	return minInt(days, maxInterval)
+}
+
+func clampDifficulty(d float64) float64 {
+	return math.Min(math.Max(d, fsrsMinDifficulty), fsrsMaxDifficulty)
+}
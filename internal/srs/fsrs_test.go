@@ -0,0 +1,137 @@
+// File: internal/srs/fsrs_test.go
+
+package srs
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+func TestFSRSGradeMapping(t *testing.T) {
+	expected := map[int]int{
+		1: fsrsAgain,
+		2: fsrsAgain,
+		3: fsrsHard,
+		4: fsrsGood,
+		5: fsrsEasy,
+	}
+
+	for rating, grade := range expected {
+		if got := fsrsGrade(rating); got != grade {
+			t.Errorf("fsrsGrade(%d) = %d, expected %d", rating, got, grade)
+		}
+	}
+}
+
+func TestScheduleCardFSRSNewCard(t *testing.T) {
+	// Better grades must give a new card a longer first interval
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	var previousInterval int
+	for rating := 2; rating <= 5; rating++ {
+		card := ScheduleCardFSRS(model.Card{}, rating, DefaultDesiredRetention, now)
+
+		if card.Stability <= 0 {
+			t.Errorf("Rating %d: expected a positive stability, got %f", rating, card.Stability)
+		}
+		if card.Difficulty < fsrsMinDifficulty || card.Difficulty > fsrsMaxDifficulty {
+			t.Errorf("Rating %d: difficulty %f outside [1, 10]", rating, card.Difficulty)
+		}
+		if card.Interval < previousInterval {
+			t.Errorf("Rating %d: interval %d shorter than %d for a worse rating", rating, card.Interval, previousInterval)
+		}
+		if card.Rating != rating {
+			t.Errorf("Expected rating %d to be stored, got %d", rating, card.Rating)
+		}
+		previousInterval = card.Interval
+	}
+}
+
+func TestScheduleCardFSRSReview(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+
+	// A card reviewed exactly when it was due
+	card := model.Card{
+		LastReviewed: now.AddDate(0, 0, -10),
+		Interval:     10,
+		Stability:    10,
+		Difficulty:   5,
+	}
+
+	passed := ScheduleCardFSRS(card, 4, DefaultDesiredRetention, now)
+	if passed.Stability <= card.Stability {
+		t.Errorf("Expected stability to grow after a successful review, got %f -> %f", card.Stability, passed.Stability)
+	}
+	if passed.Interval <= card.Interval {
+		t.Errorf("Expected interval to grow after a successful review, got %d -> %d", card.Interval, passed.Interval)
+	}
+
+	failed := ScheduleCardFSRS(card, 1, DefaultDesiredRetention, now)
+	if failed.Stability >= card.Stability {
+		t.Errorf("Expected stability to drop after a failed review, got %f -> %f", card.Stability, failed.Stability)
+	}
+	if failed.Difficulty <= card.Difficulty {
+		t.Errorf("Expected difficulty to rise after a failed review, got %f -> %f", card.Difficulty, failed.Difficulty)
+	}
+}
+
+func TestScheduleCardFSRSDesiredRetention(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	card := model.Card{
+		LastReviewed: now.AddDate(0, 0, -20),
+		Interval:     20,
+		Stability:    20,
+		Difficulty:   5,
+	}
+
+	// Asking for a higher retention must schedule the next review sooner
+	relaxed := ScheduleCardFSRS(card, 4, 0.8, now)
+	strict := ScheduleCardFSRS(card, 4, 0.95, now)
+
+	if strict.Interval >= relaxed.Interval {
+		t.Errorf("Expected retention 0.95 to give a shorter interval than 0.8, got %d >= %d", strict.Interval, relaxed.Interval)
+	}
+}
+
+func TestScheduleCardFSRSFromSM2(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+
+	// A card with an SM-2 schedule but no FSRS memory state
+	card := model.Card{
+		LastReviewed: now.AddDate(0, 0, -6),
+		Interval:     6,
+		Ease:         2.5,
+	}
+
+	updated := ScheduleCardFSRS(card, 4, DefaultDesiredRetention, now)
+
+	if updated.Stability <= float64(card.Interval) {
+		t.Errorf("Expected migrated stability to grow beyond the old interval, got %f", updated.Stability)
+	}
+	if updated.Ease != card.Ease {
+		t.Errorf("Expected ease to be left untouched, got %f", updated.Ease)
+	}
+}
+
+func TestRetrievability(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	card := model.Card{
+		LastReviewed: now.AddDate(0, 0, -10),
+		Stability:    10,
+	}
+
+	// By definition recall probability is 90% after S days
+	if r := Retrievability(card, now); math.Abs(r-0.9) > 0.001 {
+		t.Errorf("Expected retrievability 0.9 after S days, got %f", r)
+	}
+
+	if r := Retrievability(card, card.LastReviewed); r != 1 {
+		t.Errorf("Expected retrievability 1 right after a review, got %f", r)
+	}
+
+	if r := Retrievability(model.Card{}, now); r != 0 {
+		t.Errorf("Expected retrievability 0 for a card without memory state, got %f", r)
+	}
+}
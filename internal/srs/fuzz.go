@@ -0,0 +1,91 @@
+// File: internal/srs/fuzz.go
+
+package srs
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+// fuzzRanges sets how much an interval may be fuzzed: each day of the
+// interval between start and end widens the fuzz range by factor days
+var fuzzRanges = []struct {
+	start, end, factor float64
+}{
+	{2.5, 7, 0.15},
+	{7, 20, 0.1},
+	{20, math.Inf(1), 0.05},
+}
+
+// FuzzRange returns the shortest and longest interval, in days, a card
+// scheduled with the given interval may get once fuzzed. Intervals shorter
+// than three days are not fuzzed.
+func FuzzRange(interval int) (int, int) {
+	if float64(interval) < 2.5 {
+		return interval, interval
+	}
+
+	delta := 1.0
+	for _, r := range fuzzRanges {
+		delta += r.factor * math.Max(math.Min(float64(interval), r.end)-r.start, 0)
+	}
+
+	shortest := max(int(math.Round(float64(interval)-delta)), 2)
+	longest := min(int(math.Round(float64(interval)+delta)), maxInterval)
+	return min(shortest, longest), longest
+}
+
+// Fuzz spreads out the reviews of cards that would otherwise stay due on
+// the same days, by moving each review to a day near the one the scheduler
+// picked
+type Fuzz struct {
+	Rand *rand.Rand // Source of the random days
+
+	// Load returns the number of cards already due the given number of days
+	// from now. With a load, the least loaded day of the fuzz range is
+	// picked instead of a random one, ties being broken at random.
+	Load func(days int) int
+}
+
+// Apply fuzzes the interval of a card that was just scheduled at the given
+// time. Cards in learning are left as they are.
+func (f Fuzz) Apply(card model.Card, now time.Time) model.Card {
+	if card.IsLearning() || f.Rand == nil {
+		return card
+	}
+
+	shortest, longest := FuzzRange(card.Interval)
+	if shortest == longest {
+		return card
+	}
+
+	interval := shortest + f.Rand.IntN(longest-shortest+1)
+	if f.Load != nil {
+		interval = f.leastLoaded(shortest, longest)
+	}
+
+	card.Interval = interval
+	card.NextReview = now.AddDate(0, 0, interval)
+	return card
+}
+
+// leastLoaded returns the interval between shortest and longest with the
+// fewest cards due, picking one at random when several are tied
+func (f Fuzz) leastLoaded(shortest, longest int) int {
+	var best []int
+	bestLoad := 0
+	for interval := shortest; interval <= longest; interval++ {
+		load := f.Load(interval)
+		switch {
+		case len(best) == 0 || load < bestLoad:
+			best = []int{interval}
+			bestLoad = load
+		case load == bestLoad:
+			best = append(best, interval)
+		}
+	}
+	return best[f.Rand.IntN(len(best))]
+}
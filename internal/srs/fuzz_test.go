@@ -0,0 +1,76 @@
+// File: internal/srs/fuzz_test.go
+
+package srs
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+func TestFuzzRange(t *testing.T) {
+	testCases := []struct {
+		interval, shortest, longest int
+	}{
+		{1, 1, 1},
+		{2, 2, 2},
+		{3, 2, 4},
+		{7, 5, 9},
+		{30, 27, 33},
+		{365, 345, 365},
+	}
+
+	for _, tc := range testCases {
+		shortest, longest := FuzzRange(tc.interval)
+		if shortest != tc.shortest || longest != tc.longest {
+			t.Errorf("Expected interval %d to be fuzzed within %d-%d days, got %d-%d",
+				tc.interval, tc.shortest, tc.longest, shortest, longest)
+		}
+	}
+}
+
+func TestFuzzApply(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	card := model.Card{Interval: 30, NextReview: now.AddDate(0, 0, 30), State: model.StateReview}
+
+	// Random fuzz stays within the range and spreads cards out
+	fuzz := Fuzz{Rand: rand.New(rand.NewPCG(1, 2))}
+	seen := make(map[int]bool)
+	for i := 0; i < 50; i++ {
+		fuzzed := fuzz.Apply(card, now)
+		if fuzzed.Interval < 27 || fuzzed.Interval > 33 {
+			t.Fatalf("Expected a fuzzed interval within 27-33 days, got %d", fuzzed.Interval)
+		}
+		if !fuzzed.NextReview.Equal(now.AddDate(0, 0, fuzzed.Interval)) {
+			t.Fatalf("Expected the next review %d days from now, got %v", fuzzed.Interval, fuzzed.NextReview)
+		}
+		seen[fuzzed.Interval] = true
+	}
+	if len(seen) < 3 {
+		t.Errorf("Expected fuzzed intervals to vary, got %v", seen)
+	}
+
+	// Load balancing picks the least loaded day
+	fuzz.Load = func(days int) int {
+		if days == 28 {
+			return 1
+		}
+		return 10
+	}
+	if fuzzed := fuzz.Apply(card, now); fuzzed.Interval != 28 {
+		t.Errorf("Expected the least loaded interval of 28 days, got %d", fuzzed.Interval)
+	}
+
+	// Cards in learning and short intervals are left as they are
+	learning := model.Card{Interval: 30, State: model.StateRelearning, NextReview: now.Add(10 * time.Minute)}
+	if fuzzed := fuzz.Apply(learning, now); !reflect.DeepEqual(fuzzed, learning) {
+		t.Errorf("Expected a card in learning not to be fuzzed, got %+v", fuzzed)
+	}
+	short := model.Card{Interval: 1, State: model.StateReview, NextReview: now.AddDate(0, 0, 1)}
+	if fuzzed := fuzz.Apply(short, now); !reflect.DeepEqual(fuzzed, short) {
+		t.Errorf("Expected a 1 day interval not to be fuzzed, got %+v", fuzzed)
+	}
+}
@@ -0,0 +1,130 @@
+// File: internal/srs/scheduler.go
+
+package srs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+// Names of the available schedulers
+const (
+	SchedulerSM2  = "sm2"
+	SchedulerFSRS = "fsrs"
+)
+
+// Scheduler decides when a card should be reviewed next
+type Scheduler interface {
+	// Name returns the name used to select the scheduler
+	Name() string
+
+	// Schedule updates a card after it was reviewed at the given time with
+	// a 1-5 rating and returns the updated card
+	Schedule(card model.Card, rating int, now time.Time) model.Card
+
+	// InitializeCard sets up the scheduling state of a card that was never
+	// reviewed, so that it is due at the given time
+	InitializeCard(card model.Card, now time.Time) model.Card
+
+	// PreviewIntervals returns the time until the next review the card
+	// would get for each 1-5 rating if reviewed at the given time, without
+	// modifying the card
+	PreviewIntervals(card model.Card, now time.Time) map[int]time.Duration
+}
+
+// Options configures the schedulers created by NewScheduler
+type Options struct {
+	DesiredRetention float64 // Target recall probability, used by FSRS
+	Steps            Steps   // Learning and relearning steps
+	DayStartHour     int     // Local hour (0-23) at which a new day starts
+}
+
+// SchedulerNames returns the names accepted by NewScheduler
+func SchedulerNames() []string {
+	return []string{SchedulerSM2, SchedulerFSRS}
+}
+
+// NewScheduler creates the scheduler with the given name
+func NewScheduler(name string, opts Options) (Scheduler, error) {
+	switch name {
+	case SchedulerSM2, "":
+		return SM2Scheduler{Steps: opts.Steps, DayStartHour: opts.DayStartHour}, nil
+	case SchedulerFSRS:
+		return FSRSScheduler{DesiredRetention: opts.DesiredRetention, Steps: opts.Steps}, nil
+	default:
+		return nil, fmt.Errorf("unknown scheduler %q (expected %q or %q)", name, SchedulerSM2, SchedulerFSRS)
+	}
+}
+
+// SM2Scheduler schedules cards with the SM-2 algorithm
+type SM2Scheduler struct {
+	Steps        Steps
+	DayStartHour int // Local hour (0-23) at which a new day starts
+}
+
+// Name returns the name of the SM-2 scheduler
+func (SM2Scheduler) Name() string {
+	return SchedulerSM2
+}
+
+// Schedule updates a card with the SM-2 algorithm
+func (s SM2Scheduler) Schedule(card model.Card, rating int, now time.Time) model.Card {
+	return scheduleWithSteps(card, rating, now, s.Steps, func(card model.Card, rating int, now time.Time) model.Card {
+		return scheduleCardAt(card, rating, now, s.DayStartHour)
+	})
+}
+
+// InitializeCard sets up a new card for SM-2 scheduling
+func (SM2Scheduler) InitializeCard(card model.Card, now time.Time) model.Card {
+	return InitializeNewCardAt(card, now)
+}
+
+// PreviewIntervals returns the SM-2 interval for each rating
+func (s SM2Scheduler) PreviewIntervals(card model.Card, now time.Time) map[int]time.Duration {
+	return previewIntervals(s, card, now)
+}
+
+// FSRSScheduler schedules cards with the Free Spaced Repetition Scheduler
+type FSRSScheduler struct {
+	DesiredRetention float64
+	Steps            Steps
+}
+
+// Name returns the name of the FSRS scheduler
+func (FSRSScheduler) Name() string {
+	return SchedulerFSRS
+}
+
+// Schedule updates a card with the FSRS algorithm
+func (s FSRSScheduler) Schedule(card model.Card, rating int, now time.Time) model.Card {
+	return scheduleWithSteps(card, rating, now, s.Steps, func(card model.Card, rating int, now time.Time) model.Card {
+		return ScheduleCardFSRS(card, rating, s.DesiredRetention, now)
+	})
+}
+
+// InitializeCard sets up a new card for FSRS scheduling. The memory state
+// is created on the first review, so the card only needs to be due.
+func (FSRSScheduler) InitializeCard(card model.Card, now time.Time) model.Card {
+	card = InitializeNewCardAt(card, now)
+	card.Stability = 0
+	card.Difficulty = 0
+	return card
+}
+
+// PreviewIntervals returns the FSRS interval for each rating
+func (s FSRSScheduler) PreviewIntervals(card model.Card, now time.Time) map[int]time.Duration {
+	return previewIntervals(s, card, now)
+}
+
+// previewIntervals schedules a copy of the card with every rating and
+// reports the resulting time until the next review
+func previewIntervals(s Scheduler, card model.Card, now time.Time) map[int]time.Duration {
+	intervals := make(map[int]time.Duration, 5)
+	for rating := 1; rating <= 5; rating++ {
+		scheduled := s.Schedule(card, rating, now)
+		intervals[rating] = scheduled.NextReview.Sub(now)
+	}
+	return intervals
+}
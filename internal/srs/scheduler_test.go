@@ -0,0 +1,81 @@
+// File: internal/srs/scheduler_test.go
+
+package srs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+func TestNewScheduler(t *testing.T) {
+	for _, name := range SchedulerNames() {
+		scheduler, err := NewScheduler(name, Options{DesiredRetention: 0.9})
+		if err != nil {
+			t.Fatalf("NewScheduler(%q) error: %v", name, err)
+		}
+		if scheduler.Name() != name {
+			t.Errorf("Expected scheduler named %q, got %q", name, scheduler.Name())
+		}
+	}
+
+	if _, err := NewScheduler("leitner", Options{}); err == nil {
+		t.Error("Expected an error for an unknown scheduler")
+	}
+}
+
+func TestSchedulerPreviewIntervals(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	card := model.Card{
+		LastReviewed: now.AddDate(0, 0, -6),
+		Interval:     6,
+		Ease:         2.5,
+	}
+
+	for _, name := range SchedulerNames() {
+		scheduler, _ := NewScheduler(name, Options{DesiredRetention: 0.9})
+		previews := scheduler.PreviewIntervals(card, now)
+
+		if len(previews) != 5 {
+			t.Fatalf("%s: expected a preview for each of the 5 ratings, got %d", name, len(previews))
+		}
+
+		// Better ratings never schedule the card sooner
+		for rating := 2; rating <= 5; rating++ {
+			if previews[rating] < previews[rating-1] {
+				t.Errorf("%s: rating %d previews %v, shorter than %v for rating %d",
+					name, rating, previews[rating], previews[rating-1], rating-1)
+			}
+		}
+
+		// Previewing must not modify the card
+		if card.Interval != 6 || card.Rating != 0 {
+			t.Errorf("%s: expected card to be unchanged by preview, got %+v", name, card)
+		}
+
+		// The preview matches what scheduling the card actually does
+		scheduled := scheduler.Schedule(card, 4, now)
+		if got := scheduled.NextReview.Sub(now); got != previews[4] {
+			t.Errorf("%s: expected preview %v to match scheduled interval %v", name, previews[4], got)
+		}
+	}
+}
+
+func TestSchedulerInitializeCard(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	card := model.Card{Interval: 5, Stability: 3, Difficulty: 4}
+
+	sm2 := SM2Scheduler{}.InitializeCard(card, now)
+	if sm2.Interval != 0 || sm2.Ease != defaultEase {
+		t.Errorf("Expected SM-2 to reset interval and set default ease, got %+v", sm2)
+	}
+
+	fsrs := FSRSScheduler{DesiredRetention: 0.9}.InitializeCard(card, now)
+	if fsrs.Stability != 0 || fsrs.Difficulty != 0 {
+		t.Errorf("Expected FSRS to clear the memory state, got %+v", fsrs)
+	}
+	if !fsrs.NextReview.Equal(now) {
+		t.Errorf("Expected a new card to be due at %v, got %v", now, fsrs.NextReview)
+	}
+}
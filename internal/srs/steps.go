@@ -0,0 +1,171 @@
+// File: internal/srs/steps.go
+
+package srs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+// Default learning and relearning steps
+var (
+	DefaultLearningSteps   = []time.Duration{time.Minute, 10 * time.Minute}
+	DefaultRelearningSteps = []time.Duration{10 * time.Minute}
+)
+
+// Steps are the short delays, in minutes or hours, a card goes through
+// before it is reviewed at intervals of days. New cards go through the
+// learning steps and forgotten cards through the relearning steps. Without
+// steps, cards go straight to day intervals.
+type Steps struct {
+	Learning   []time.Duration
+	Relearning []time.Duration
+}
+
+// ParseSteps reads a list of steps such as "1m 10m 1h" or "1m, 10m, 1d".
+// Units are those of time.ParseDuration, plus "d" for days.
+func ParseSteps(value string) ([]time.Duration, error) {
+	var steps []time.Duration
+	for _, field := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' }) {
+		step, err := parseStep(field)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// parseStep reads a single step
+func parseStep(value string) (time.Duration, error) {
+	var step time.Duration
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid step %q", value)
+		}
+		step = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if step, err = time.ParseDuration(value); err != nil {
+			return 0, fmt.Errorf("invalid step %q", value)
+		}
+	}
+
+	if step <= 0 {
+		return 0, fmt.Errorf("invalid step %q: steps must be longer than zero", value)
+	}
+	return step, nil
+}
+
+// FormatSteps writes steps the way ParseSteps reads them
+func FormatSteps(steps []time.Duration) string {
+	formatted := make([]string, len(steps))
+	for i, step := range steps {
+		switch {
+		case step%(24*time.Hour) == 0:
+			formatted[i] = fmt.Sprintf("%dd", step/(24*time.Hour))
+		case step%time.Hour == 0:
+			formatted[i] = fmt.Sprintf("%dh", step/time.Hour)
+		case step%time.Minute == 0:
+			formatted[i] = fmt.Sprintf("%dm", step/time.Minute)
+		default:
+			formatted[i] = step.String()
+		}
+	}
+	return strings.Join(formatted, " ")
+}
+
+// scheduleWithSteps moves a card through the learning and relearning steps
+// and leaves day intervals to schedule, the scheduling algorithm.
+//
+// Rating 1 or 2 starts the steps over, 3 repeats the current step, 4 moves
+// to the next step and 5 skips the remaining steps. A card that finishes its
+// learning steps is scheduled by the algorithm with the rating that finished
+// them; without learning steps, new cards are scheduled by the algorithm
+// whatever the rating. A forgotten review card counts a lapse and is
+// scheduled by the algorithm first, so that the lapse lowers its ease, and
+// then goes through the relearning steps before its new interval starts.
+func scheduleWithSteps(card model.Card, rating int, now time.Time, steps Steps,
+	schedule func(model.Card, int, time.Time) model.Card) model.Card {
+
+	switch stateOf(card) {
+	case model.StateNew, model.StateLearning:
+		if card.State == model.StateNew {
+			card.Step = 0
+		}
+		if next, ok := nextStep(card, rating, steps.Learning); ok {
+			return atStep(card, rating, now, model.StateLearning, next, steps.Learning)
+		}
+
+		// Graduate with the first interval of the algorithm
+		return inReview(schedule(card, rating, now))
+
+	case model.StateRelearning:
+		if next, ok := nextStep(card, rating, steps.Relearning); ok {
+			return atStep(card, rating, now, model.StateRelearning, next, steps.Relearning)
+		}
+
+		// Back to day intervals, starting from the interval set at the lapse
+		card.LastReviewed = now
+		card.Rating = rating
+		card.NextReview = now.AddDate(0, 0, max(card.Interval, 1))
+		return inReview(card)
+
+	default:
+		lapsed := schedule(card, rating, now)
+		if rating <= 2 {
+			lapsed.Lapses++
+		}
+		if rating > 2 || len(steps.Relearning) == 0 {
+			return inReview(lapsed)
+		}
+		return atStep(lapsed, rating, now, model.StateRelearning, 0, steps.Relearning)
+	}
+}
+
+// stateOf returns the state of a card. Cards that were reviewed before
+// states were recorded are treated as review cards.
+func stateOf(card model.Card) model.CardState {
+	if card.State == model.StateNew && !card.LastReviewed.IsZero() {
+		return model.StateReview
+	}
+	return card.State
+}
+
+// nextStep returns the step a rating moves a card in steps to, or false
+// when the card is done with the steps
+func nextStep(card model.Card, rating int, steps []time.Duration) (int, bool) {
+	step := min(card.Step, len(steps)-1)
+	switch {
+	case len(steps) == 0 || rating == 5:
+		return 0, false
+	case rating <= 2:
+		return 0, true
+	case rating == 3:
+		return max(step, 0), true
+	default:
+		return step + 1, step+1 < len(steps)
+	}
+}
+
+// atStep puts a card at a learning or relearning step
+func atStep(card model.Card, rating int, now time.Time, state model.CardState, step int, steps []time.Duration) model.Card {
+	card.LastReviewed = now
+	card.Rating = rating
+	card.State = state
+	card.Step = step
+	card.NextReview = now.Add(steps[step])
+	return card
+}
+
+// inReview marks a card as reviewed at intervals of days
+func inReview(card model.Card) model.Card {
+	card.State = model.StateReview
+	card.Step = 0
+	return card
+}
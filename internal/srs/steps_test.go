@@ -0,0 +1,111 @@
+// File: internal/srs/steps_test.go
+
+package srs
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+func TestParseSteps(t *testing.T) {
+	steps, err := ParseSteps("1m, 10m 1h 2d")
+	if err != nil {
+		t.Fatalf("ParseSteps error: %v", err)
+	}
+
+	expected := []time.Duration{time.Minute, 10 * time.Minute, time.Hour, 48 * time.Hour}
+	if !reflect.DeepEqual(steps, expected) {
+		t.Errorf("Expected steps %v, got %v", expected, steps)
+	}
+
+	if formatted := FormatSteps(steps); formatted != "1m 10m 1h 2d" {
+		t.Errorf("Expected steps formatted as %q, got %q", "1m 10m 1h 2d", formatted)
+	}
+
+	if steps, err := ParseSteps(""); err != nil || len(steps) != 0 {
+		t.Errorf("Expected no steps for an empty value, got %v, %v", steps, err)
+	}
+
+	for _, value := range []string{"10", "1x", "-5m", "0m", "d"} {
+		if _, err := ParseSteps(value); err == nil {
+			t.Errorf("Expected an error for steps %q", value)
+		}
+	}
+}
+
+func TestLearningSteps(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	scheduler := SM2Scheduler{Steps: Steps{
+		Learning:   []time.Duration{time.Minute, 10 * time.Minute},
+		Relearning: []time.Duration{10 * time.Minute},
+	}}
+	card := InitializeNewCardAt(model.Card{}, now)
+
+	// Good moves a new card to the next step
+	card = scheduler.Schedule(card, 4, now)
+	if card.State != model.StateLearning || card.Step != 1 || !card.NextReview.Equal(now.Add(10*time.Minute)) {
+		t.Fatalf("Expected learning step 1 due in 10 minutes, got %v step %d due %v", card.State, card.Step, card.NextReview)
+	}
+
+	// Again starts the steps over
+	now = now.Add(10 * time.Minute)
+	card = scheduler.Schedule(card, 1, now)
+	if card.State != model.StateLearning || card.Step != 0 || !card.NextReview.Equal(now.Add(time.Minute)) {
+		t.Fatalf("Expected learning step 0 due in a minute, got %v step %d due %v", card.State, card.Step, card.NextReview)
+	}
+
+	// Hard repeats the step
+	now = now.Add(time.Minute)
+	card = scheduler.Schedule(card, 3, now)
+	if card.Step != 0 || !card.NextReview.Equal(now.Add(time.Minute)) {
+		t.Fatalf("Expected to repeat step 0, got step %d due %v", card.Step, card.NextReview)
+	}
+
+	// Finishing the last step graduates the card to an interval of days
+	now = now.Add(time.Minute)
+	card = scheduler.Schedule(card, 4, now)
+	now = now.Add(10 * time.Minute)
+	card = scheduler.Schedule(card, 4, now)
+	if card.State != model.StateReview || card.Interval != 1 || !card.NextReview.Equal(now.AddDate(0, 0, 1)) {
+		t.Fatalf("Expected the card to graduate to a 1 day interval, got %v interval %d due %v", card.State, card.Interval, card.NextReview)
+	}
+
+	// Forgetting a review card lowers its ease and starts relearning
+	card.Interval = 20
+	now = now.AddDate(0, 0, 20)
+	ease := card.Ease
+	card = scheduler.Schedule(card, 1, now)
+	if card.State != model.StateRelearning || !card.NextReview.Equal(now.Add(10*time.Minute)) {
+		t.Fatalf("Expected relearning due in 10 minutes, got %v due %v", card.State, card.NextReview)
+	}
+	if card.Ease >= ease {
+		t.Errorf("Expected the lapse to lower the ease from %v, got %v", ease, card.Ease)
+	}
+
+	// Finishing relearning goes back to the interval set at the lapse
+	now = now.Add(10 * time.Minute)
+	card = scheduler.Schedule(card, 4, now)
+	if card.State != model.StateReview || !card.NextReview.Equal(now.AddDate(0, 0, card.Interval)) {
+		t.Errorf("Expected review in %d days, got %v due %v", card.Interval, card.State, card.NextReview)
+	}
+}
+
+func TestLearningStepsEasy(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	scheduler := SM2Scheduler{Steps: Steps{Learning: DefaultLearningSteps}}
+
+	// Easy skips the remaining steps
+	card := scheduler.Schedule(InitializeNewCardAt(model.Card{}, now), 5, now)
+	if card.State != model.StateReview || card.Interval < 1 {
+		t.Errorf("Expected easy to graduate the card, got %v interval %d", card.State, card.Interval)
+	}
+
+	// Without steps cards go straight to intervals of days
+	card = SM2Scheduler{}.Schedule(InitializeNewCardAt(model.Card{}, now), 1, now)
+	if card.State != model.StateReview || card.Interval != 1 {
+		t.Errorf("Expected a 1 day interval without steps, got %v interval %d", card.State, card.Interval)
+	}
+}
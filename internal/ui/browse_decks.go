@@ -4,9 +4,10 @@ package ui
 
 import (
 	"fmt"
-	"time"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/DavidMiserak/GoCard/internal/data"
@@ -20,13 +21,17 @@ const (
 
 // Key mapping for browse screen
 type browseKeyMap struct {
-	Up    key.Binding
-	Down  key.Binding
-	Enter key.Binding
-	Back  key.Binding
-	Next  key.Binding
-	Prev  key.Binding
-	Quit  key.Binding
+	Up      key.Binding
+	Down    key.Binding
+	Enter   key.Binding
+	Study   key.Binding
+	Back    key.Binding
+	Next    key.Binding
+	Prev    key.Binding
+	Toggle  key.Binding
+	NewCard key.Binding
+	NewDeck key.Binding
+	Quit    key.Binding
 }
 
 var browseKeys = browseKeyMap{
@@ -40,7 +45,11 @@ var browseKeys = browseKeyMap{
 	),
 	Enter: key.NewBinding(
 		key.WithKeys("enter"),
-		key.WithHelp("enter", "study"),
+		key.WithHelp("enter", "cards"),
+	),
+	Study: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "study"),
 	),
 	Back: key.NewBinding(
 		key.WithKeys("b"),
@@ -54,35 +63,90 @@ var browseKeys = browseKeyMap{
 		key.WithKeys("p", "left", "h"), // "h" for Vim users
 		key.WithHelp("n/p", "next/prev page"),
 	),
+	Toggle: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "expand/collapse"),
+	),
+	NewCard: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "new card"),
+	),
+	NewDeck: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "new deck"),
+	),
 	Quit: key.NewBinding(
 		key.WithKeys("q", "ctrl+c"),
 		key.WithHelp("q", "quit"),
 	),
 }
 
+// deckRow is a deck as listed in the deck tree
+type deckRow struct {
+	deck        model.Deck // The deck with the cards of its sub-decks
+	depth       int        // 0 for top-level decks
+	hasSubDecks bool
+}
+
 // BrowseScreen represents the browse decks screen
 type BrowseScreen struct {
 	store        *data.Store
-	decks        []model.Deck
+	decks        []deckRow       // Visible rows of the deck tree
+	expanded     map[string]bool // Decks whose sub-decks are shown
 	cursor       int
 	page         int
 	totalPages   int
 	width        int
 	height       int
 	selectedDeck string
+	naming       bool            // Whether the name of a new deck is being typed
+	nameInput    textinput.Model // Name of the new deck
+	status       string          // Outcome of the last deck created
 }
 
 // NewBrowseScreen creates a new browse screen
 func NewBrowseScreen(store *data.Store) *BrowseScreen {
-	decks := store.GetDecks()
-	totalPages := (len(decks) + decksPerPage - 1) / decksPerPage // Ceiling division
-
-	return &BrowseScreen{
-		store:      store,
-		decks:      decks,
-		cursor:     0,
-		page:       0,
-		totalPages: totalPages,
+	b := &BrowseScreen{
+		store:    store,
+		expanded: make(map[string]bool),
+		cursor:   0,
+		page:     0,
+	}
+	b.refreshRows()
+
+	b.nameInput = textinput.New()
+	b.nameInput.Placeholder = "Deck name"
+	b.nameInput.CharLimit = 100
+	b.nameInput.Width = 40
+
+	return b
+}
+
+// refreshRows lists the top-level decks and the sub-decks of expanded decks
+func (b *BrowseScreen) refreshRows() {
+	b.decks = nil
+	for _, deck := range b.store.GetDecks() {
+		if deck.ParentID == "" {
+			b.addRows(deck, 0)
+		}
+	}
+	b.totalPages = (len(b.decks) + decksPerPage - 1) / decksPerPage // Ceiling division
+
+	// Keep the cursor on a row when decks were removed or collapsed
+	b.page = max(min(b.page, b.totalPages-1), 0)
+	b.cursor = max(min(b.cursor, min(decksPerPage, len(b.decks)-b.page*decksPerPage)-1), 0)
+}
+
+// addRows adds a deck and, if it is expanded, its sub-decks to the rows
+func (b *BrowseScreen) addRows(deck model.Deck, depth int) {
+	subDecks := b.store.GetSubDecks(deck.ID)
+	tree, _ := b.store.GetDeckWithSubDecks(deck.ID)
+	b.decks = append(b.decks, deckRow{deck: tree, depth: depth, hasSubDecks: len(subDecks) > 0})
+
+	if b.expanded[deck.ID] {
+		for _, subDeck := range subDecks {
+			b.addRows(subDeck, depth+1)
+		}
 	}
 }
 
@@ -93,8 +157,13 @@ func (b BrowseScreen) Init() tea.Cmd {
 
 // Update handles user input and updates the model
 func (b BrowseScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if b.naming {
+		return b.updateNaming(msg)
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		b.status = ""
 		switch {
 		case key.Matches(msg, browseKeys.Quit):
 			return b, tea.Quit
@@ -123,6 +192,15 @@ func (b BrowseScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				b.cursor = 0
 			}
 
+		case key.Matches(msg, browseKeys.Toggle):
+			// Show or hide the sub-decks of the selected deck
+			deckIndex := (b.page * decksPerPage) + b.cursor
+			if deckIndex < len(b.decks) && b.decks[deckIndex].hasSubDecks {
+				id := b.decks[deckIndex].deck.ID
+				b.expanded[id] = !b.expanded[id]
+				b.refreshRows()
+			}
+
 		case key.Matches(msg, browseKeys.Back):
 			// Return to main menu
 			return NewMainMenu(b.store), nil
@@ -131,12 +209,38 @@ func (b BrowseScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Get the selected deck
 			deckIndex := (b.page * decksPerPage) + b.cursor
 			if deckIndex < len(b.decks) {
-				b.selectedDeck = b.decks[deckIndex].ID
+				b.selectedDeck = b.decks[deckIndex].deck.ID
+				// List the cards of the selected deck
+				return NewCardBrowserScreen(b.store, b.selectedDeck), nil
+			}
+
+		case key.Matches(msg, browseKeys.Study):
+			// Get the selected deck
+			deckIndex := (b.page * decksPerPage) + b.cursor
+			if deckIndex < len(b.decks) {
+				b.selectedDeck = b.decks[deckIndex].deck.ID
 				// Navigate to study screen with the selected deck
 				return NewStudyScreen(b.store, b.selectedDeck), nil
 			}
+
+		case key.Matches(msg, browseKeys.NewCard):
+			// Write new cards into the selected deck
+			deckIndex := (b.page * decksPerPage) + b.cursor
+			if deckIndex < len(b.decks) {
+				return NewCardFormScreen(b.store, b.decks[deckIndex].deck.ID), nil
+			}
+
+		case key.Matches(msg, browseKeys.NewDeck):
+			// Ask for the name of the new deck
+			b.naming = true
+			b.nameInput.Reset()
+			return b, b.nameInput.Focus()
 		}
 
+	case FilesChangedMsg:
+		// Show the new card counts
+		b.refreshRows()
+
 	case tea.WindowSizeMsg:
 		b.width = 120 // Default width
 		b.height = msg.Height
@@ -145,6 +249,49 @@ func (b BrowseScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return b, nil
 }
 
+// updateNaming handles user input while the name of a new deck is typed
+func (b BrowseScreen) updateNaming(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "ctrl+c":
+			return b, tea.Quit
+
+		case "esc":
+			b.naming = false
+			b.nameInput.Blur()
+			return b, nil
+
+		case "enter":
+			deck, err := b.store.CreateDeck(b.nameInput.Value())
+			if err != nil {
+				b.status = fmt.Sprintf("Deck not created: %v", err)
+				return b, nil
+			}
+			b.naming = false
+			b.nameInput.Blur()
+			b.status = fmt.Sprintf("Deck %s created, press c to add cards", deck.Name)
+			b.refreshRows()
+			b.selectDeck(deck.ID)
+			return b, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	b.nameInput, cmd = b.nameInput.Update(msg)
+	return b, cmd
+}
+
+// selectDeck moves the cursor to the row of a deck
+func (b *BrowseScreen) selectDeck(deckID string) {
+	for i, row := range b.decks {
+		if row.deck.ID == deckID {
+			b.page = i / decksPerPage
+			b.cursor = i % decksPerPage
+			return
+		}
+	}
+}
+
 // View renders the browse screen
 func (b BrowseScreen) View() string {
 	// Title
@@ -161,12 +308,14 @@ func (b BrowseScreen) View() string {
 	endIdx := min(startIdx+decksPerPage, len(b.decks))
 	displayDecks := b.decks[startIdx:endIdx]
 
-	// Display each deck
-	for i, deck := range displayDecks {
+	// Display each deck, counting the cards of its sub-decks
+	for i, row := range displayDecks {
+		deck := row.deck
+
 		// Count due cards
 		dueCards := 0
 		for _, card := range deck.Cards {
-			if card.NextReview.Before(time.Now()) {
+			if b.store.IsDue(card) {
 				dueCards++
 			}
 		}
@@ -174,30 +323,41 @@ func (b BrowseScreen) View() string {
 		// Format the last studied date
 		lastStudied := "Never"
 		if !deck.LastStudied.IsZero() {
-			if isToday(deck.LastStudied) {
+			daysAgo := -b.store.DaysFromToday(deck.LastStudied)
+			switch {
+			case daysAgo == 0:
 				lastStudied = "Today"
-			} else if isYesterday(deck.LastStudied) {
+			case daysAgo == 1:
 				lastStudied = "Yesterday"
-			} else if isWithinDays(deck.LastStudied, 7) {
-				days := daysBetween(deck.LastStudied, time.Now())
-				lastStudied = fmt.Sprintf("%d days ago", days)
-			} else {
+			case daysAgo < 7:
+				lastStudied = fmt.Sprintf("%d days ago", daysAgo)
+			default:
 				lastStudied = deck.LastStudied.Format("Jan 2")
 			}
 		}
 
+		// Indent sub-decks and mark decks that have some
+		name := strings.Repeat("  ", row.depth) + deck.Name
+		if row.hasSubDecks {
+			marker := "▸ "
+			if b.expanded[deck.ID] {
+				marker = "▾ "
+			}
+			name = strings.Repeat("  ", row.depth) + marker + deck.Name
+		}
+
 		// Format the row
-		row := fmt.Sprintf("%-20s %-10d %-10d %-15s",
-			truncate(deck.Name, 20),
+		line := fmt.Sprintf("%-20s %-10d %-10d %-15s",
+			truncate(name, 20),
 			len(deck.Cards),
 			dueCards,
 			lastStudied)
 
 		// Highlight the selected row
 		if i == b.cursor {
-			s += selectedRowStyle.Render("> " + row)
+			s += selectedRowStyle.Render("> " + line)
 		} else {
-			s += normalRowStyle.Render("  " + row)
+			s += normalRowStyle.Render("  " + line)
 		}
 		s += "\n"
 	}
@@ -208,8 +368,21 @@ func (b BrowseScreen) View() string {
 	s += paginationStyle.Render(pagination)
 	s += "\n\n"
 
+	// The name of the new deck and how creating it went
+	if b.naming {
+		s += normalRowStyle.Render("New deck: ") + b.nameInput.View()
+		s += "\n\n"
+	}
+	if b.status != "" {
+		s += statLabelStyle.Render(b.status)
+		s += "\n\n"
+	}
+
 	// Help text
-	help := "\t↑/↓: Navigate" + "\tEnter: Study\t" + "b: Back" + "\tn/p: Next/Prev Page" + "\tq: Quit"
+	help := "\t↑/↓: Navigate" + "\tEnter: Cards" + "\ts: Study" + "\tSpace: Expand\t" + "b: Back" + "\tn/p: Next/Prev Page" + "\tc: New Card" + "\tN: New Deck" + "\tq: Quit"
+	if b.naming {
+		help = "\tEnter: Create" + "\tEsc: Cancel" + "\tCtrl+C: Quit"
+	}
 	s += browseHelpStyle.Render(help)
 
 	return s
@@ -225,26 +398,17 @@ func min(a, b int) int {
 }
 
 func truncate(s string, max int) string {
-	if len(s) <= max {
+	runes := []rune(s)
+	if len(runes) <= max {
 		return s
 	}
-	return s[:max-3] + "..."
+	return string(runes[:max-3]) + "..."
 }
 
-func isToday(t time.Time) bool {
-	now := time.Now()
-	return t.Year() == now.Year() && t.Month() == now.Month() && t.Day() == now.Day()
-}
-
-func isYesterday(t time.Time) bool {
-	yesterday := time.Now().AddDate(0, 0, -1)
-	return t.Year() == yesterday.Year() && t.Month() == yesterday.Month() && t.Day() == yesterday.Day()
-}
-
-func isWithinDays(t time.Time, days int) bool {
-	return time.Since(t).Hours() < float64(days)*24
-}
-
-func daysBetween(a, b time.Time) int {
-	return int(b.Sub(a).Hours() / 24)
+// deckName returns the name of a deck, or its ID if it is not in the store
+func deckName(store *data.Store, deckID string) string {
+	if deck, found := store.GetDeck(deckID); found {
+		return deck.Name
+	}
+	return deckID
 }
@@ -9,6 +9,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/DavidMiserak/GoCard/internal/data"
+	"github.com/DavidMiserak/GoCard/internal/model"
 )
 
 func TestBrowseScreenView(t *testing.T) {
@@ -127,6 +128,52 @@ func TestBrowseScreenPagination(t *testing.T) {
 	}
 }
 
+func TestBrowseScreenFilesChanged(t *testing.T) {
+	store := &data.Store{Config: data.DefaultConfig()}
+	for _, id := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		store.Decks = append(store.Decks, model.Deck{ID: id, Name: id})
+	}
+
+	// Select the last deck, on the second page
+	var m tea.Model = NewBrowseScreen(store)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+	// Decks removed from the second page move the cursor to the last row left
+	store.Decks = store.Decks[:6]
+	m, _ = m.Update(FilesChangedMsg{})
+	if browse := m.(BrowseScreen); browse.page != 1 || browse.cursor != 0 {
+		t.Errorf("Expected the cursor on the last deck, got page %d row %d", browse.page, browse.cursor)
+	}
+
+	// Without a second page, the cursor moves back to the first
+	store.Decks = store.Decks[:3]
+	m, _ = m.Update(FilesChangedMsg{})
+	if browse := m.(BrowseScreen); browse.page != 0 || browse.cursor != 0 {
+		t.Errorf("Expected the cursor on the first page, got page %d row %d", browse.page, browse.cursor)
+	}
+	if view := m.View(); !strings.Contains(view, "> a") || !strings.Contains(view, "Page 1 of 1") {
+		t.Errorf("Expected the first deck selected, got:\n%s", view)
+	}
+
+	// Rows removed below the cursor move it to the last row left
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	store.Decks = store.Decks[:2]
+	m, _ = m.Update(FilesChangedMsg{})
+	if browse := m.(BrowseScreen); browse.cursor != 1 {
+		t.Errorf("Expected the cursor on the last deck, got row %d", browse.cursor)
+	}
+
+	// Removing every deck leaves nothing selected
+	store.Decks = nil
+	m, _ = m.Update(FilesChangedMsg{})
+	if browse := m.(BrowseScreen); browse.page != 0 || browse.cursor != 0 {
+		t.Errorf("Expected the first row, got page %d row %d", browse.page, browse.cursor)
+	}
+	m.View()
+}
+
 func TestBrowseScreenBackButton(t *testing.T) {
 	// Create a store with dummy data
 	store := data.NewStore()
@@ -146,3 +193,58 @@ func TestBrowseScreenBackButton(t *testing.T) {
 		t.Fatalf("Expected *MainMenu after back key, got %T", updatedModel)
 	}
 }
+
+func TestBrowseScreenDeckTree(t *testing.T) {
+	// A parent deck with one sub-deck, next to a top-level deck
+	store := &data.Store{
+		Config: data.DefaultConfig(),
+		Decks: []model.Deck{
+			{ID: "programming", Name: "Programming", Cards: []model.Card{{ID: "p1", DeckID: "programming"}}},
+			{ID: "go", Name: "Go", ParentID: "programming", Cards: []model.Card{
+				{ID: "g1", DeckID: "go"},
+				{ID: "g2", DeckID: "go"},
+			}},
+			{ID: "spanish", Name: "Spanish", Cards: []model.Card{{ID: "s1", DeckID: "spanish"}}},
+		},
+	}
+
+	browse := NewBrowseScreen(store)
+
+	// Sub-decks start collapsed and their cards count towards the parent
+	if len(browse.decks) != 2 {
+		t.Fatalf("Expected 2 top-level rows, got %d", len(browse.decks))
+	}
+	view := browse.View()
+	if !strings.Contains(view, "▸ Programming") || strings.Contains(view, "Go ") {
+		t.Errorf("Expected a collapsed Programming deck, got:\n%s", view)
+	}
+	if cards := len(browse.decks[0].deck.Cards); cards != 3 {
+		t.Errorf("Expected 3 cards in the Programming tree, got %d", cards)
+	}
+
+	// Space expands the selected deck
+	spaceMsg := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	updatedModel, _ := browse.Update(spaceMsg)
+	updatedBrowse, ok := updatedModel.(BrowseScreen)
+	if !ok {
+		t.Fatalf("Expected BrowseScreen, got %T", updatedModel)
+	}
+	if len(updatedBrowse.decks) != 3 || updatedBrowse.decks[1].deck.ID != "go" || updatedBrowse.decks[1].depth != 1 {
+		t.Fatalf("Expected the Go sub-deck below Programming, got %+v", updatedBrowse.decks)
+	}
+	if view := updatedBrowse.View(); !strings.Contains(view, "▾ Programming") || !strings.Contains(view, "  Go") {
+		t.Errorf("Expected an expanded Programming deck, got:\n%s", view)
+	}
+
+	// Space again collapses it
+	updatedModel, _ = updatedBrowse.Update(spaceMsg)
+	if updatedBrowse = updatedModel.(BrowseScreen); len(updatedBrowse.decks) != 2 {
+		t.Errorf("Expected 2 rows after collapsing, got %d", len(updatedBrowse.decks))
+	}
+
+	// Studying the parent deck includes the cards of its sub-decks
+	study := NewStudyScreen(store, "programming")
+	if study.totalCards != 3 {
+		t.Errorf("Expected 3 cards to study, got %d", study.totalCards)
+	}
+}
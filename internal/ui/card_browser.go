@@ -0,0 +1,698 @@
+// File: internal/ui/card_browser.go
+
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/DavidMiserak/GoCard/internal/data"
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+const (
+	// Number of cards to display per page
+	cardsPerPage = 10
+
+	// Number of lines of the question and answer shown in the detail pane
+	cardDetailLines = 6
+
+	// Width of the schedule in the detail pane, and of the question and
+	// answer beside it until the window size is known
+	cardFieldsWidth = 40
+	cardDetailWidth = 70
+)
+
+// cardSort is a column the card browser can be sorted by
+type cardSort int
+
+const (
+	sortByDue cardSort = iota
+	sortByEase
+	sortByInterval
+	sortByRating
+	sortByFile
+)
+
+// cardSortNames are the names of the sort columns, in the order they are
+// cycled through
+var cardSortNames = []string{"due date", "ease", "interval", "last rating", "file name"}
+
+// Key mapping for the card browser
+type cardBrowserKeyMap struct {
+	Up         key.Binding
+	Down       key.Binding
+	Next       key.Binding
+	Prev       key.Binding
+	Sort       key.Binding
+	Reverse    key.Binding
+	Open       key.Binding
+	Reset      key.Binding
+	Reschedule key.Binding
+	Suspend    key.Binding
+	Edit       key.Binding
+	Back       key.Binding
+	Quit       key.Binding
+}
+
+var cardBrowserKeys = cardBrowserKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("up", "k"), // "k" for Vim users
+		key.WithHelp("↑/k", "navigate"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "j"), // "j" for Vim users
+		key.WithHelp("↓/j", "navigate"),
+	),
+	Next: key.NewBinding(
+		key.WithKeys("n", "pgdown", "right", "l"), // "l" for Vim users
+		key.WithHelp("n/p", "next/prev page"),
+	),
+	Prev: key.NewBinding(
+		key.WithKeys("p", "pgup", "left", "h"), // "h" for Vim users
+		key.WithHelp("n/p", "next/prev page"),
+	),
+	Sort: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "sort"),
+	),
+	Reverse: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "reverse order"),
+	),
+	Open: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "open"),
+	),
+	Reset: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "reset"),
+	),
+	Reschedule: key.NewBinding(
+		key.WithKeys("d"),
+		key.WithHelp("d", "reschedule"),
+	),
+	Suspend: key.NewBinding(
+		key.WithKeys("@"),
+		key.WithHelp("@", "suspend/unsuspend"),
+	),
+	Edit: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "edit"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("b", "esc"),
+		key.WithHelp("b", "back"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("q", "ctrl+c"),
+		key.WithHelp("q", "quit"),
+	),
+}
+
+// CardBrowserScreen lists the cards of a deck and its sub-decks with their
+// schedule, and shows the selected card in a detail pane
+type CardBrowserScreen struct {
+	store            *data.Store
+	deckID           string
+	deckName         string
+	cards            []model.Card
+	fileNames        map[string]string // Name of each card, by card ID
+	sortBy           cardSort
+	descending       bool
+	cursor           int  // Index of the selected card in cards
+	opened           bool // Showing the selected card in full
+	confirmReset     bool // Asking whether to reset the selected card
+	rescheduling     bool // Asking when the selected card is due
+	dueInput         textinput.Model
+	cardViewport     viewport.Model
+	markdownRenderer *MarkdownRenderer
+	status           string // Outcome of the last action
+	width            int
+	height           int
+}
+
+// NewCardBrowserScreen creates a card browser for a deck
+func NewCardBrowserScreen(store *data.Store, deckID string) *CardBrowserScreen {
+	dueInput := textinput.New()
+	dueInput.Placeholder = "days from today, or YYYY-MM-DD"
+	dueInput.CharLimit = 20
+	dueInput.Width = 30
+
+	cardViewport := viewport.New(80, 20)
+	cardViewport.Style = viewportStyle
+
+	c := &CardBrowserScreen{
+		store:            store,
+		deckID:           deckID,
+		dueInput:         dueInput,
+		cardViewport:     cardViewport,
+		markdownRenderer: NewMarkdownRenderer(cardDetailWidth, "solarized-dark"),
+	}
+	c.refresh()
+
+	return c
+}
+
+// Init initializes the card browser
+func (c CardBrowserScreen) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles user input and updates the model
+func (c CardBrowserScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case c.rescheduling:
+			return c.updateRescheduling(msg)
+
+		case c.confirmReset:
+			c.confirmReset = false
+			c.status = ""
+			if msg.String() == "y" {
+				c.apply(c.store.ResetCard, "Reset to a new card")
+			}
+			return c, nil
+
+		case c.opened:
+			return c.updateOpened(msg)
+		}
+
+		switch {
+		case key.Matches(msg, cardBrowserKeys.Quit):
+			return c, tea.Quit
+
+		case key.Matches(msg, cardBrowserKeys.Back):
+			// Return to the deck list
+			return NewBrowseScreen(c.store), nil
+
+		case key.Matches(msg, cardBrowserKeys.Up):
+			if c.cursor > 0 {
+				c.cursor--
+			}
+
+		case key.Matches(msg, cardBrowserKeys.Down):
+			if c.cursor < len(c.cards)-1 {
+				c.cursor++
+			}
+
+		case key.Matches(msg, cardBrowserKeys.Next):
+			c.cursor = min(c.cursor+cardsPerPage, max(len(c.cards)-1, 0))
+
+		case key.Matches(msg, cardBrowserKeys.Prev):
+			c.cursor = max(c.cursor-cardsPerPage, 0)
+
+		case key.Matches(msg, cardBrowserKeys.Sort):
+			c.sortBy = (c.sortBy + 1) % cardSort(len(cardSortNames))
+			c.descending = false
+			c.sortCards()
+
+		case key.Matches(msg, cardBrowserKeys.Reverse):
+			c.descending = !c.descending
+			c.sortCards()
+
+		case key.Matches(msg, cardBrowserKeys.Open):
+			if card, ok := c.selected(); ok {
+				c.open(card)
+			}
+
+		case key.Matches(msg, cardBrowserKeys.Reset):
+			if _, ok := c.selected(); ok {
+				c.confirmReset = true
+				c.status = "Reset this card to a new card? y/n"
+			}
+
+		case key.Matches(msg, cardBrowserKeys.Reschedule):
+			if card, ok := c.selected(); ok {
+				if card.LastReviewed.IsZero() {
+					c.status = "New cards are studied before they are scheduled"
+					break
+				}
+				c.rescheduling = true
+				c.status = ""
+				c.dueInput.Reset()
+				return c, c.dueInput.Focus()
+			}
+
+		case key.Matches(msg, cardBrowserKeys.Edit):
+			if card, ok := c.selected(); ok {
+				var cmd tea.Cmd
+				cmd, c.status = editCard(c.store, card.ID)
+				return c, cmd
+			}
+
+		case key.Matches(msg, cardBrowserKeys.Suspend):
+			if card, ok := c.selected(); ok {
+				if card.Suspended {
+					c.apply(c.store.UnsuspendCard, "Unsuspended")
+				} else {
+					c.apply(c.store.SuspendCard, "Suspended")
+				}
+			}
+		}
+
+	case cardEditedMsg:
+		c.status = reloadEditedCard(c.store, msg)
+		c.refresh()
+
+	case FilesChangedMsg:
+		c.refresh()
+
+	case tea.WindowSizeMsg:
+		c.width = 120 // Default width
+		c.height = msg.Height
+
+		c.markdownRenderer.UpdateWidth(c.width - cardFieldsWidth - 10) // Beside the schedule
+		c.cardViewport.Width = c.width - 10
+		c.cardViewport.Height = max(c.height-8, 5)
+		if card, ok := c.selected(); ok && c.opened {
+			c.open(card)
+		}
+	}
+
+	return c, nil
+}
+
+// updateOpened handles keys while a card is opened in full
+func (c CardBrowserScreen) updateOpened(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, cardBrowserKeys.Quit):
+		return c, tea.Quit
+
+	case key.Matches(msg, cardBrowserKeys.Back):
+		c.opened = false
+		return c, nil
+
+	case key.Matches(msg, cardBrowserKeys.Edit):
+		card, ok := c.selected()
+		if !ok {
+			// The card is gone, go back to the list
+			c.opened = false
+			return c, nil
+		}
+		var cmd tea.Cmd
+		cmd, c.status = editCard(c.store, card.ID)
+		return c, cmd
+	}
+
+	// Scroll the card
+	var cmd tea.Cmd
+	c.cardViewport, cmd = c.cardViewport.Update(msg)
+	return c, cmd
+}
+
+// updateRescheduling handles keys while asking when the selected card is due
+func (c CardBrowserScreen) updateRescheduling(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		c.rescheduling = false
+		c.dueInput.Blur()
+		return c, nil
+
+	case tea.KeyEnter:
+		due, err := c.parseDue(c.dueInput.Value())
+		if err != nil {
+			c.status = err.Error()
+			return c, nil
+		}
+
+		c.rescheduling = false
+		c.dueInput.Blur()
+		c.apply(func(cardID string) error {
+			return c.store.RescheduleCard(cardID, due)
+		}, "Due "+due.Format("Jan 2, 2006"))
+		return c, nil
+	}
+
+	var cmd tea.Cmd
+	c.dueInput, cmd = c.dueInput.Update(msg)
+	return c, cmd
+}
+
+// parseDue reads when a card should be due: a number of days from today or
+// a date
+func (c CardBrowserScreen) parseDue(text string) (time.Time, error) {
+	text = strings.TrimSpace(text)
+	if days, err := strconv.Atoi(text); err == nil {
+		if days < 0 {
+			return time.Time{}, fmt.Errorf("cards cannot be due in the past")
+		}
+		return c.store.Today().AddDate(0, 0, days), nil
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", text, c.store.Now().Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("enter a number of days or a date like 2025-04-30")
+	}
+	due := c.store.StartOfDay(date.Add(time.Duration(c.store.Config.DayStartHour) * time.Hour))
+	if due.Before(c.store.Today()) {
+		return time.Time{}, fmt.Errorf("cards cannot be due in the past")
+	}
+	return due, nil
+}
+
+// apply runs an action on the selected card and shows its outcome
+func (c *CardBrowserScreen) apply(action func(cardID string) error, done string) {
+	card, ok := c.selected()
+	if !ok {
+		return
+	}
+
+	if err := action(card.ID); err != nil {
+		c.status = fmt.Sprintf("Error: %v", err)
+		return
+	}
+	c.status = done + ": " + truncate(firstLine(card.Question), 40)
+	c.refresh()
+}
+
+// refresh lists the cards of the deck in the store again, keeping the
+// selected card selected
+func (c *CardBrowserScreen) refresh() {
+	selected, hadSelection := c.selected()
+
+	deck, _ := c.store.GetDeckWithSubDecks(c.deckID)
+	c.deckName = deck.Name
+	c.cards = append([]model.Card(nil), deck.Cards...)
+	c.fileNames = make(map[string]string, len(c.cards))
+	for _, card := range c.cards {
+		c.fileNames[card.ID] = c.cardFileName(card)
+	}
+	c.sortCards()
+
+	c.cursor = 0
+	if hadSelection {
+		for i, card := range c.cards {
+			if card.ID == selected.ID {
+				c.cursor = i
+				break
+			}
+		}
+	}
+
+	card, ok := c.selected()
+	switch {
+	case c.opened && ok && card.ID == selected.ID:
+		c.open(card) // Show the new version of the card
+	case c.opened:
+		c.opened = false // The card is gone
+	}
+}
+
+// sortCards orders the cards by the sort column, then by file name
+func (c *CardBrowserScreen) sortCards() {
+	selected, hadSelection := c.selected()
+
+	sort.SliceStable(c.cards, func(i, j int) bool {
+		a, b := c.cards[i], c.cards[j]
+		if c.descending {
+			a, b = b, a
+		}
+		if less, equal := c.compare(a, b); !equal {
+			return less
+		}
+		return a.ID < b.ID
+	})
+
+	if hadSelection {
+		for i, card := range c.cards {
+			if card.ID == selected.ID {
+				c.cursor = i
+				break
+			}
+		}
+	}
+}
+
+// compare orders two cards by the sort column, reporting whether a comes
+// before b or whether they are equal in that column. New cards are due
+// before reviewed ones, as they are studied first.
+func (c CardBrowserScreen) compare(a, b model.Card) (less, equal bool) {
+	switch c.sortBy {
+	case sortByEase:
+		return a.Ease < b.Ease, a.Ease == b.Ease
+	case sortByInterval:
+		return a.Interval < b.Interval, a.Interval == b.Interval
+	case sortByRating:
+		return a.Rating < b.Rating, a.Rating == b.Rating
+	case sortByFile:
+		return c.fileName(a) < c.fileName(b), c.fileName(a) == c.fileName(b)
+	default:
+		aNew, bNew := a.LastReviewed.IsZero(), b.LastReviewed.IsZero()
+		if aNew != bNew {
+			return aNew, false
+		}
+		return a.NextReview.Before(b.NextReview), a.NextReview.Equal(b.NextReview)
+	}
+}
+
+// selected returns the selected card
+func (c CardBrowserScreen) selected() (model.Card, bool) {
+	if c.cursor < 0 || c.cursor >= len(c.cards) {
+		return model.Card{}, false
+	}
+	return c.cards[c.cursor], true
+}
+
+// open shows a card in full, with its schedule, question and answer
+func (c *CardBrowserScreen) open(card model.Card) {
+	content := strings.Join(c.cardFields(card), "\n") + "\n\n" +
+		c.markdownRenderer.Render(card.Question) + "\n\n" +
+		answerStyle.Render("Answer") + "\n\n" +
+		c.markdownRenderer.Render(card.Answer)
+	c.cardViewport.SetContent(content)
+	c.cardViewport.GotoTop()
+	c.opened = true
+}
+
+// View renders the card browser
+func (c CardBrowserScreen) View() string {
+	if c.opened {
+		return c.viewOpened()
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(headerStyle.Render("Cards: " + c.deckName))
+	sb.WriteString("\n\n")
+
+	if len(c.cards) == 0 {
+		sb.WriteString(normalRowStyle.Render("This deck has no cards."))
+		sb.WriteString("\n\n")
+	} else {
+		// Header row, marking the sort column
+		order := "▲"
+		if c.descending {
+			order = "▼"
+		}
+		columns := []string{"FILE", "DUE", "INTERVAL", "EASE", "RATING", "STATE"}
+		sortColumn := map[cardSort]int{sortByFile: 0, sortByDue: 1, sortByInterval: 2, sortByEase: 3, sortByRating: 4}[c.sortBy]
+		columns[sortColumn] += " " + order
+		headerRow := fmt.Sprintf("  %-36s %-14s %-10s %-8s %-8s %-12s", columns[0], columns[1], columns[2], columns[3], columns[4], columns[5])
+		sb.WriteString(headerStyle.Render(headerRow))
+		sb.WriteString("\n")
+
+		// Display the cards on the page of the cursor
+		startIdx := c.cursor / cardsPerPage * cardsPerPage
+		endIdx := min(startIdx+cardsPerPage, len(c.cards))
+		for i := startIdx; i < endIdx; i++ {
+			card := c.cards[i]
+			row := fmt.Sprintf("%-36s %-14s %-10s %-8s %-8s %-12s",
+				truncate(c.fileName(card), 36),
+				c.dueLabel(card),
+				intervalLabel(card),
+				fmt.Sprintf("%.2f", card.Ease),
+				ratingName(card.Rating),
+				c.stateLabel(card),
+			)
+
+			if i == c.cursor {
+				sb.WriteString(selectedRowStyle.Render("> " + row))
+			} else {
+				sb.WriteString(normalRowStyle.Render("  " + row))
+			}
+			sb.WriteString("\n")
+		}
+
+		// Position in the list
+		sb.WriteString("\n")
+		sb.WriteString(paginationStyle.Render(fmt.Sprintf("%d of %d, sorted by %s", c.cursor+1, len(c.cards), cardSortNames[c.sortBy])))
+		sb.WriteString("\n\n")
+
+		// Detail pane of the selected card
+		if card, ok := c.selected(); ok {
+			sb.WriteString(c.detailPane(card))
+			sb.WriteString("\n\n")
+		}
+	}
+
+	if c.rescheduling {
+		sb.WriteString(statLabelStyle.Render("Due in: "))
+		sb.WriteString(c.dueInput.View())
+		sb.WriteString("\n\n")
+	}
+	if c.status != "" {
+		sb.WriteString(statLabelStyle.Render(c.status))
+		sb.WriteString("\n\n")
+	}
+
+	// Help text
+	help := "\t↑/↓: Navigate" + "\tEnter: Open" + "\ts/S: Sort/Reverse" + "\te: Edit" + "\tr: Reset" + "\td: Reschedule" + "\t@: Suspend" + "\tb: Back" + "\tq: Quit"
+	sb.WriteString(browseHelpStyle.Render(help))
+
+	return sb.String()
+}
+
+// viewOpened renders the selected card in full
+func (c CardBrowserScreen) viewOpened() string {
+	card, _ := c.selected()
+
+	sb := strings.Builder{}
+	sb.WriteString(headerStyle.Render(c.deckName + " / " + c.fileName(card)))
+	sb.WriteString("\n\n")
+	sb.WriteString(c.cardViewport.View())
+	sb.WriteString("\n\n")
+
+	if c.status != "" {
+		sb.WriteString(statLabelStyle.Render(c.status))
+		sb.WriteString("\n\n")
+	}
+
+	help := "\t↑/↓: Scroll" + "\te: Edit" + "\tb: Back to Cards" + "\tq: Quit"
+	sb.WriteString(browseHelpStyle.Render(help))
+
+	return sb.String()
+}
+
+// detailPane renders the schedule of a card next to the beginning of its
+// question and answer
+func (c CardBrowserScreen) detailPane(card model.Card) string {
+	text := clipLines(c.markdownRenderer.Render(card.Question), cardDetailLines) + "\n\n" +
+		answerStyle.Render("Answer") + "\n\n" +
+		clipLines(c.markdownRenderer.Render(card.Answer), cardDetailLines)
+
+	fields := statLabelStyle.Width(cardFieldsWidth).Render(strings.Join(c.cardFields(card), "\n"))
+	return lipgloss.JoinHorizontal(lipgloss.Top, fields, text)
+}
+
+// cardFields describes the schedule of a card, one field per line
+func (c CardBrowserScreen) cardFields(card model.Card) []string {
+	lastReviewed := "Never"
+	if !card.LastReviewed.IsZero() {
+		lastReviewed = card.LastReviewed.Format("Jan 2, 2006 15:04")
+	}
+
+	fields := []string{
+		"Deck:          " + deckName(c.store, card.DeckID),
+		"State:         " + c.stateLabel(card),
+		"Due:           " + c.dueLabel(card),
+		"Last reviewed: " + lastReviewed,
+		"Last rating:   " + ratingName(card.Rating),
+		"Interval:      " + intervalLabel(card),
+		fmt.Sprintf("Ease:          %.2f", card.Ease),
+	}
+	if card.State == model.StateLearning || card.State == model.StateRelearning {
+		fields = append(fields, fmt.Sprintf("Step:          %d", card.Step+1))
+	}
+	if card.Stability > 0 {
+		fields = append(fields,
+			fmt.Sprintf("Stability:     %.1f days", card.Stability),
+			fmt.Sprintf("Difficulty:    %.1f", card.Difficulty),
+		)
+	}
+	fields = append(fields, fmt.Sprintf("Lapses:        %d", card.Lapses))
+	if len(card.Tags) > 0 {
+		fields = append(fields, "Tags:          "+strings.Join(card.Tags, ", "))
+	}
+	if !card.Created.IsZero() {
+		fields = append(fields, "Created:       "+card.Created.Format("Jan 2, 2006"))
+	}
+	return fields
+}
+
+// fileName names a listed card by its file, as found when the cards were
+// listed
+func (c CardBrowserScreen) fileName(card model.Card) string {
+	if name, ok := c.fileNames[card.ID]; ok {
+		return name
+	}
+	return c.cardFileName(card)
+}
+
+// cardFileName names a card by its file, relative to the deck for cards of
+// sub-decks, or by its question for cards without a file
+func (c CardBrowserScreen) cardFileName(card model.Card) string {
+	if _, ok := c.store.CardFile(card.ID); !ok {
+		return firstLine(card.Question)
+	}
+	if rel, err := filepath.Rel(c.deckID, card.ID); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return filepath.Base(card.ID)
+}
+
+// dueLabel tells when a card is due
+func (c CardBrowserScreen) dueLabel(card model.Card) string {
+	if card.LastReviewed.IsZero() {
+		return "New"
+	}
+	switch days := c.store.DaysFromToday(card.NextReview); {
+	case days < 0:
+		return "Overdue"
+	case days == 0:
+		return "Today"
+	default:
+		return card.NextReview.In(c.store.Now().Location()).Format("Jan 2, 2006")
+	}
+}
+
+// stateLabel tells where a card is in learning, and whether it is set aside
+func (c CardBrowserScreen) stateLabel(card model.Card) string {
+	switch {
+	case card.Suspended:
+		return "suspended"
+	case c.store.IsBuried(card):
+		return "buried"
+	default:
+		return card.State.String()
+	}
+}
+
+// intervalLabel formats the interval of a card in days
+func intervalLabel(card model.Card) string {
+	if card.LastReviewed.IsZero() {
+		return "-"
+	}
+	if card.Interval == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", card.Interval)
+}
+
+// ratingName names the last rating of a card
+func ratingName(rating int) string {
+	names := []string{"-", "Blackout", "Wrong", "Hard", "Good", "Easy"}
+	if rating < 0 || rating >= len(names) {
+		return strconv.Itoa(rating)
+	}
+	return names[rating]
+}
+
+// clipLines keeps the first lines of a text, marking that it goes on
+func clipLines(text string, lines int) string {
+	split := strings.Split(text, "\n")
+	if len(split) > lines {
+		split = append(split[:lines], "…")
+	}
+	return strings.Join(split, "\n")
+}
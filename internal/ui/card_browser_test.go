@@ -0,0 +1,217 @@
+// File: internal/ui/card_browser_test.go
+
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/DavidMiserak/GoCard/internal/clock"
+	"github.com/DavidMiserak/GoCard/internal/data"
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+// newScheduledStore returns a store with a deck of cards on different
+// schedules
+func newScheduledStore() *data.Store {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	reviewed := func(id string, due, interval int, ease float64, rating int) model.Card {
+		return model.Card{
+			ID: id, DeckID: "go", Question: id + "?", Answer: id,
+			LastReviewed: now.AddDate(0, 0, due-interval), NextReview: now.AddDate(0, 0, due),
+			Interval: interval, Ease: ease, Rating: rating, State: model.StateReview,
+		}
+	}
+
+	store := &data.Store{
+		Config: data.DefaultConfig(),
+		Decks: []model.Deck{
+			{ID: "go", Name: "Go", Cards: []model.Card{
+				reviewed("channels", 5, 10, 2.3, 4),
+				{ID: "slices", DeckID: "go", Question: "slices?", Answer: "slices", NextReview: now, Ease: 2.5},
+				reviewed("goroutines", 2, 3, 2.7, 5),
+				reviewed("maps", -1, 20, 1.9, 3),
+			}},
+		},
+	}
+	store.SetClock(clock.Fixed(now))
+	return store
+}
+
+// browserCardIDs returns the IDs of the cards listed by a card browser
+func browserCardIDs(m tea.Model) []string {
+	c := m.(CardBrowserScreen)
+	ids := make([]string, len(c.cards))
+	for i, card := range c.cards {
+		ids[i] = card.ID
+	}
+	return ids
+}
+
+func TestCardBrowserSorting(t *testing.T) {
+	store := newScheduledStore()
+
+	// Enter in the deck list lists the cards of the deck, s studies it
+	m, _ := NewBrowseScreen(store).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	if _, ok := m.(*StudyScreen); !ok {
+		t.Errorf("Expected *StudyScreen after s, got %T", m)
+	}
+	m, _ = NewBrowseScreen(store).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if _, ok := m.(*CardBrowserScreen); !ok {
+		t.Fatalf("Expected *CardBrowserScreen after enter, got %T", m)
+	}
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	// New cards come first by due date, then the soonest due
+	expected := map[string][]string{
+		"due date":    {"slices", "maps", "goroutines", "channels"},
+		"ease":        {"maps", "channels", "slices", "goroutines"},
+		"interval":    {"slices", "goroutines", "channels", "maps"},
+		"last rating": {"slices", "maps", "channels", "goroutines"},
+		"file name":   {"channels", "goroutines", "maps", "slices"}, // By question without a file
+	}
+	for _, name := range cardSortNames {
+		view := m.View()
+		if !strings.Contains(view, "sorted by "+name) {
+			t.Errorf("Expected cards sorted by %s, got:\n%s", name, view)
+		}
+
+		if got := browserCardIDs(m); strings.Join(got, " ") != strings.Join(expected[name], " ") {
+			t.Errorf("Expected cards sorted by %s to be %v, got %v", name, expected[name], got)
+		}
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	}
+
+	// S reverses the order
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	if got := browserCardIDs(m); got[0] != "channels" || got[3] != "slices" {
+		t.Errorf("Expected the order reversed, got %v", got)
+	}
+
+	// The selection follows the card when sorting, so go to the top
+	for i := 0; i < 3; i++ {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	}
+
+	// The detail pane shows the schedule of the selected card
+	view := m.View()
+	for _, field := range []string{"State:         review", "Interval:      10 days", "Ease:          2.30", "Last rating:   Good"} {
+		if !strings.Contains(view, field) {
+			t.Errorf("Expected detail pane to show %q, got:\n%s", field, view)
+		}
+	}
+
+	// Enter opens the card in full, b goes back to the list
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if view := m.View(); !strings.Contains(view, "Answer") || strings.Contains(view, "sorted by") {
+		t.Errorf("Expected the opened card, got:\n%s", view)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	if view := m.View(); !strings.Contains(view, "sorted by") {
+		t.Errorf("Expected the card list again, got:\n%s", view)
+	}
+}
+
+func TestCardBrowserActions(t *testing.T) {
+	store := newScheduledStore()
+	var m tea.Model = NewCardBrowserScreen(store, "go")
+	key := func(r rune) {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+
+	// The first card by due date is the new one: it cannot be rescheduled
+	key('d')
+	if view := m.View(); !strings.Contains(view, "New cards are studied before they are scheduled") {
+		t.Errorf("Expected new cards not to be rescheduled, got:\n%s", view)
+	}
+
+	// Reschedule the overdue card three days from today
+	key('j')
+	key('d')
+	m = typeText(m, "3")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	card, _ := store.GetCard("maps")
+	if due := store.Today().AddDate(0, 0, 3); !card.NextReview.Equal(due) {
+		t.Errorf("Expected the card due %v, got %v", due, card.NextReview)
+	}
+	if selected, _ := m.(CardBrowserScreen).selected(); selected.ID != "maps" {
+		t.Errorf("Expected the rescheduled card to stay selected, got %s", selected.ID)
+	}
+
+	// Suspend and unsuspend it
+	key('@')
+	if card, _ := store.GetCard("maps"); !card.Suspended {
+		t.Error("Expected the card to be suspended")
+	}
+	if view := m.View(); !strings.Contains(view, "suspended") {
+		t.Errorf("Expected the card shown as suspended, got:\n%s", view)
+	}
+	key('@')
+	if card, _ := store.GetCard("maps"); card.Suspended {
+		t.Error("Expected the card to be unsuspended")
+	}
+
+	// Cards without a file cannot be edited
+	key('e')
+	if view := m.View(); !strings.Contains(view, "This card has no file to edit") {
+		t.Errorf("Expected cards without a file not to be edited, got:\n%s", view)
+	}
+
+	// Reset asks first
+	key('r')
+	key('n')
+	if card, _ := store.GetCard("maps"); card.LastReviewed.IsZero() {
+		t.Error("Expected the card not to be reset without confirmation")
+	}
+	key('r')
+	key('y')
+	if card, _ := store.GetCard("maps"); !card.LastReviewed.IsZero() || card.State != model.StateNew {
+		t.Errorf("Expected the card to be reset, got %+v", card)
+	}
+
+	// b goes back to the deck list
+	key('b')
+	if _, ok := m.(*BrowseScreen); !ok {
+		t.Errorf("Expected *BrowseScreen after b, got %T", m)
+	}
+}
+
+func TestCardBrowserEditRemovedCard(t *testing.T) {
+	store := newScheduledStore()
+	var m tea.Model = NewCardBrowserScreen(store, "go")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.(CardBrowserScreen).opened {
+		t.Fatal("Expected the card to be opened")
+	}
+
+	// The cards are removed while the card is opened
+	store.Decks[0].Cards = nil
+	m, _ = m.Update(FilesChangedMsg{})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	if m.(CardBrowserScreen).opened {
+		t.Error("Expected to go back to the list when the card is gone")
+	}
+}
+
+func TestCardBrowserOpenedCardRemoved(t *testing.T) {
+	store := newScheduledStore()
+	var m tea.Model = NewCardBrowserScreen(store, "go")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	opened, _ := m.(CardBrowserScreen).selected()
+
+	// The opened card is removed, the other cards are still listed
+	var cards []model.Card
+	for _, card := range store.Decks[0].Cards {
+		if card.ID != opened.ID {
+			cards = append(cards, card)
+		}
+	}
+	store.Decks[0].Cards = cards
+	m, _ = m.Update(FilesChangedMsg{})
+	if c := m.(CardBrowserScreen); c.opened || len(c.cards) != 3 {
+		t.Errorf("Expected to go back to the list of 3 cards, got opened %v with %d cards", c.opened, len(c.cards))
+	}
+}
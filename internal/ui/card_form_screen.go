@@ -0,0 +1,343 @@
+// File: internal/ui/card_form_screen.go
+
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/DavidMiserak/GoCard/internal/data"
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+const (
+	// Width of the fields of the new card form
+	cardFormWidth = 60
+
+	// Height of the question and answer fields
+	cardFormTextHeight = 6
+
+	// Width of the preview beside the form
+	cardPreviewWidth = 50
+
+	// Number of lines of the preview shown
+	cardPreviewLines = 20
+)
+
+// Fields of the new card form, in the order tab goes through them
+const (
+	fieldDeck = iota
+	fieldQuestion
+	fieldAnswer
+	fieldTags
+	cardFormFields
+)
+
+// Key mapping for the new card form. Letters are typed into the fields, so
+// only special keys are bound.
+type cardFormKeyMap struct {
+	Next     key.Binding
+	Prev     key.Binding
+	NextDeck key.Binding
+	PrevDeck key.Binding
+	Save     key.Binding
+	Back     key.Binding
+	Quit     key.Binding
+}
+
+var cardFormKeys = cardFormKeyMap{
+	Next: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "next field"),
+	),
+	Prev: key.NewBinding(
+		key.WithKeys("shift+tab"),
+		key.WithHelp("shift+tab", "previous field"),
+	),
+	NextDeck: key.NewBinding(
+		key.WithKeys("right", "down"),
+		key.WithHelp("→", "next deck"),
+	),
+	PrevDeck: key.NewBinding(
+		key.WithKeys("left", "up"),
+		key.WithHelp("←", "previous deck"),
+	),
+	Save: key.NewBinding(
+		key.WithKeys("ctrl+s"),
+		key.WithHelp("ctrl+s", "save"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "back"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("ctrl+c"),
+		key.WithHelp("ctrl+c", "quit"),
+	),
+}
+
+// CardFormScreen is a form to write a new card into a deck, with a preview
+// of its markdown as it is typed
+type CardFormScreen struct {
+	store            *data.Store
+	decks            []model.Deck
+	deckIndex        int // Deck the card is added to
+	question         textarea.Model
+	answer           textarea.Model
+	tags             textinput.Model
+	focus            int    // Field being edited
+	status           string // Outcome of the last save
+	fromBrowser      bool   // Whether esc goes back to the deck browser
+	markdownRenderer *MarkdownRenderer
+	width            int
+	height           int
+}
+
+// NewCardFormScreen creates a form to add cards to a deck. Without a deck
+// ID, the cards go to the first deck unless another one is picked, and the
+// form goes back to the main menu.
+func NewCardFormScreen(store *data.Store, deckID string) *CardFormScreen {
+	newTextArea := func(placeholder string) textarea.Model {
+		area := textarea.New()
+		area.Placeholder = placeholder
+		area.ShowLineNumbers = false
+		area.CharLimit = 0 // No limit
+		area.SetWidth(cardFormWidth)
+		area.SetHeight(cardFormTextHeight)
+		return area
+	}
+
+	tags := textinput.New()
+	tags.Placeholder = "go, concurrency"
+	tags.CharLimit = 200
+	tags.Width = cardFormWidth
+
+	c := &CardFormScreen{
+		store:            store,
+		decks:            store.GetDecks(),
+		question:         newTextArea("Markdown for the question"),
+		answer:           newTextArea("Markdown for the answer"),
+		tags:             tags,
+		fromBrowser:      deckID != "",
+		markdownRenderer: NewMarkdownRenderer(cardPreviewWidth, "solarized-dark"),
+	}
+	for i, deck := range c.decks {
+		if deck.ID == deckID {
+			c.deckIndex = i
+		}
+	}
+
+	// Start with the question once the deck is known
+	c.focus = fieldDeck
+	if c.fromBrowser {
+		c.focus = fieldQuestion
+	}
+	c.focusField(c.focus)
+
+	return c
+}
+
+// Init starts the cursor blinking
+func (c CardFormScreen) Init() tea.Cmd {
+	return textarea.Blink
+}
+
+// Update handles user input and updates the model
+func (c CardFormScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, cardFormKeys.Quit):
+			return c, tea.Quit
+
+		case key.Matches(msg, cardFormKeys.Back):
+			if c.fromBrowser {
+				return NewBrowseScreen(c.store), nil
+			}
+			return NewMainMenu(c.store), nil
+
+		case key.Matches(msg, cardFormKeys.Save):
+			c.save()
+			return c, c.focusField(c.focus)
+
+		case key.Matches(msg, cardFormKeys.Next):
+			return c, c.focusField((c.focus + 1) % cardFormFields)
+
+		case key.Matches(msg, cardFormKeys.Prev):
+			return c, c.focusField((c.focus + cardFormFields - 1) % cardFormFields)
+
+		case c.focus == fieldDeck && key.Matches(msg, cardFormKeys.NextDeck):
+			if len(c.decks) > 0 {
+				c.deckIndex = (c.deckIndex + 1) % len(c.decks)
+			}
+			return c, nil
+
+		case c.focus == fieldDeck && key.Matches(msg, cardFormKeys.PrevDeck):
+			if len(c.decks) > 0 {
+				c.deckIndex = (c.deckIndex + len(c.decks) - 1) % len(c.decks)
+			}
+			return c, nil
+		}
+		c.status = ""
+
+	case tea.WindowSizeMsg:
+		c.width = 120 // Default width
+		c.height = msg.Height
+		return c, nil
+	}
+
+	// Everything else edits the field being edited
+	var cmd tea.Cmd
+	switch c.focus {
+	case fieldQuestion:
+		c.question, cmd = c.question.Update(msg)
+	case fieldAnswer:
+		c.answer, cmd = c.answer.Update(msg)
+	case fieldTags:
+		c.tags, cmd = c.tags.Update(msg)
+	}
+	return c, cmd
+}
+
+// focusField moves the cursor to a field of the form
+func (c *CardFormScreen) focusField(field int) tea.Cmd {
+	c.focus = field
+	c.question.Blur()
+	c.answer.Blur()
+	c.tags.Blur()
+
+	switch field {
+	case fieldQuestion:
+		return c.question.Focus()
+	case fieldAnswer:
+		return c.answer.Focus()
+	case fieldTags:
+		return c.tags.Focus()
+	}
+	return nil
+}
+
+// save writes the card of the form to its deck and clears the form for the
+// next card, keeping its deck and tags
+func (c *CardFormScreen) save() {
+	if len(c.decks) == 0 {
+		c.status = "There is no deck to add the card to"
+		return
+	}
+
+	card, err := c.store.CreateCard(c.decks[c.deckIndex].ID, c.question.Value(), c.answer.Value(), parseTags(c.tags.Value()))
+	if err != nil {
+		c.status = fmt.Sprintf("Card not saved: %v", err)
+		return
+	}
+
+	c.status = fmt.Sprintf("Card saved to %s", filepath.Base(card.ID))
+	c.question.Reset()
+	c.answer.Reset()
+	c.focus = fieldQuestion
+}
+
+// parseTags splits the tags typed into the form, separated by commas or
+// spaces
+func parseTags(value string) []string {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, tag := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// deckLabel names a deck with the decks it is in
+func deckLabel(store *data.Store, deck model.Deck) string {
+	label := deck.Name
+	for deck.ParentID != "" {
+		parent, found := store.GetDeck(deck.ParentID)
+		if !found {
+			break
+		}
+		label = parent.Name + " / " + label
+		deck = parent
+	}
+	return label
+}
+
+// View renders the new card form
+func (c CardFormScreen) View() string {
+	var sb strings.Builder
+
+	sb.WriteString(headerStyle.Render("New Card"))
+	sb.WriteString("\n\n")
+
+	sb.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, c.formView(), "    ", c.previewView()))
+	sb.WriteString("\n\n")
+
+	if c.status != "" {
+		sb.WriteString(statLabelStyle.Render(c.status))
+		sb.WriteString("\n\n")
+	}
+
+	help := "\tTab: Next field" + "\t←/→: Deck" + "\tCtrl+S: Save" + "\tEsc: Back" + "\tCtrl+C: Quit"
+	sb.WriteString(browseHelpStyle.Render(help))
+
+	return sb.String()
+}
+
+// formView renders the fields of the form
+func (c CardFormScreen) formView() string {
+	var sb strings.Builder
+
+	// The deck picker
+	deck := "No decks"
+	if len(c.decks) > 0 {
+		deck = "◂ " + deckLabel(c.store, c.decks[c.deckIndex]) + " ▸"
+	}
+	if c.focus == fieldDeck {
+		sb.WriteString(selectedRowStyle.Render("Deck: " + deck))
+	} else {
+		sb.WriteString(normalRowStyle.Render("Deck: " + deck))
+	}
+	sb.WriteString("\n\n")
+
+	sb.WriteString(statLabelStyle.Render("Question"))
+	sb.WriteString("\n")
+	sb.WriteString(c.question.View())
+	sb.WriteString("\n\n")
+
+	sb.WriteString(statLabelStyle.Render("Answer"))
+	sb.WriteString("\n")
+	sb.WriteString(c.answer.View())
+	sb.WriteString("\n\n")
+
+	sb.WriteString(statLabelStyle.Render("Tags"))
+	sb.WriteString("\n")
+	sb.WriteString(c.tags.View())
+
+	return sb.String()
+}
+
+// previewView renders the markdown of the answer while it is edited, and
+// of the question otherwise
+func (c CardFormScreen) previewView() string {
+	title, text := "Question", c.question.Value()
+	if c.focus == fieldAnswer {
+		title, text = "Answer", c.answer.Value()
+	}
+
+	preview := statLabelStyle.Render("Nothing to preview yet")
+	if strings.TrimSpace(text) != "" {
+		preview = clipLines(c.markdownRenderer.Render(text), cardPreviewLines)
+	}
+	return answerStyle.Render(title+" preview") + "\n\n" + preview
+}
@@ -0,0 +1,134 @@
+// File: internal/ui/card_form_screen_test.go
+
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/DavidMiserak/GoCard/internal/clock"
+	"github.com/DavidMiserak/GoCard/internal/data"
+)
+
+// newCollectionStore returns a store loaded from a collection directory
+// with an empty go deck and an empty rust deck
+func newCollectionStore(t *testing.T) (*data.Store, string) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+
+	tempDir := t.TempDir()
+	for _, deck := range []string{"go", "rust"} {
+		if err := os.Mkdir(filepath.Join(tempDir, deck), 0755); err != nil {
+			t.Fatalf("Failed to create deck dir: %v", err)
+		}
+	}
+
+	store, err := data.NewStoreFromDirWithClock(tempDir, clock.Fixed(now))
+	if err != nil {
+		t.Fatalf("NewStoreFromDirWithClock error: %v", err)
+	}
+	return store, tempDir
+}
+
+func TestCardFormScreen(t *testing.T) {
+	store, tempDir := newCollectionStore(t)
+
+	// New Card is the fifth entry of the main menu
+	var m tea.Model = NewMainMenu(store)
+	for i := 0; i < 4; i++ {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if _, ok := m.(*CardFormScreen); !ok {
+		t.Fatalf("Expected *CardFormScreen after selecting New Card, got %T", m)
+	}
+
+	// Pick the rust deck, then fill in the card
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if view := m.View(); !strings.Contains(view, "◂ rust ▸") {
+		t.Errorf("Expected the rust deck picked, got:\n%s", view)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = typeText(m, "What is a borrow?")
+	if view := m.View(); !strings.Contains(view, "Question preview") || !strings.Contains(view, "borrow?") {
+		t.Errorf("Expected a preview of the question, got:\n%s", view)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = typeText(m, "A reference")
+	if view := m.View(); !strings.Contains(view, "Answer preview") {
+		t.Errorf("Expected a preview of the answer, got:\n%s", view)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = typeText(m, "rust, ownership rust")
+
+	// Saving writes the card and clears the form for the next one
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	path := filepath.Join(tempDir, "rust", "what_is_a_borrow-.md")
+	if view := m.View(); !strings.Contains(view, "Card saved to what_is_a_borrow-.md") {
+		t.Errorf("Expected the card saved, got:\n%s", view)
+	}
+	card, found := store.GetCard(path)
+	if !found || card.Answer != "A reference" || strings.Join(card.Tags, ",") != "rust,ownership" {
+		t.Errorf("Expected the card in the store, got %+v", card)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("Expected the card file to be written, got %v", err)
+	}
+	if form := m.(CardFormScreen); form.question.Value() != "" || form.focus != fieldQuestion || form.tags.Value() == "" {
+		t.Errorf("Expected an empty question and answer with the tags kept, got %q", form.question.Value())
+	}
+
+	// Cards need a question and an answer
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if view := m.View(); !strings.Contains(view, "Card not saved") {
+		t.Errorf("Expected an empty card not to be saved, got:\n%s", view)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if _, ok := m.(*MainMenu); !ok {
+		t.Errorf("Expected *MainMenu after esc, got %T", m)
+	}
+}
+
+func TestBrowseScreenNewDeck(t *testing.T) {
+	store, tempDir := newCollectionStore(t)
+
+	// N asks for the name of the new deck
+	var m tea.Model = NewBrowseScreen(store)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}})
+	m = typeText(m, "python")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if view := m.View(); !strings.Contains(view, "Deck python created") || !strings.Contains(view, "> python") {
+		t.Errorf("Expected the new deck selected, got:\n%s", view)
+	}
+	if info, err := os.Stat(filepath.Join(tempDir, "python")); err != nil || !info.IsDir() {
+		t.Errorf("Expected the deck directory to be created, got %v", err)
+	}
+
+	// Decks are not created twice
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}})
+	m = typeText(m, "python")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if view := m.View(); !strings.Contains(view, "Deck not created") {
+		t.Errorf("Expected the deck not to be created twice, got:\n%s", view)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	// c adds cards to the selected deck, esc goes back to the decks
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	form, ok := m.(*CardFormScreen)
+	if !ok {
+		t.Fatalf("Expected *CardFormScreen after c, got %T", m)
+	}
+	if deck := form.decks[form.deckIndex]; deck.Name != "python" || form.focus != fieldQuestion {
+		t.Errorf("Expected the question of a python card to be edited, got deck %s", deck.Name)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if _, ok := m.(*BrowseScreen); !ok {
+		t.Errorf("Expected *BrowseScreen after esc, got %T", m)
+	}
+}
@@ -30,8 +30,8 @@ func renderDeckReviewStats(store *data.Store, focusDeckID string) string {
 		return "No deck available to show statistics."
 	}
 
-	// Find the deck
-	deck, found := store.GetDeck(deckID)
+	// Find the deck, with the cards of its sub-decks
+	deck, found := store.GetDeckWithSubDecks(deckID)
 	if !found {
 		return "Selected deck not found."
 	}
@@ -45,10 +45,10 @@ func renderDeckReviewStats(store *data.Store, focusDeckID string) string {
 	totalCards := len(deck.Cards)
 	matureCards := getDeckMatureCards(deck)
 	newCards := totalCards - matureCards
-	successRate := calculateDeckSuccessRate(deck)
+	successRate := calculateDeckSuccessRate(store, deck)
 	avgInterval := calculateDeckAverageInterval(deck)
 	lastStudied := deck.LastStudied
-	ratingDistribution := calculateDeckRatingDistribution(deck)
+	ratingDistribution := calculateDeckRatingDistribution(store, deck)
 
 	// Layout the stats in two columns
 	leftWidth := 20
@@ -64,7 +64,7 @@ func renderDeckReviewStats(store *data.Store, focusDeckID string) string {
 	// Format the average interval with one decimal place
 	intervalStr := fmt.Sprintf("%.1f days", avgInterval)
 	// Format the last studied date
-	lastStudiedStr := formatLastStudied(lastStudied)
+	lastStudiedStr := formatLastStudied(store, lastStudied)
 
 	// Right column stats
 	rightColumn := lipgloss.JoinVertical(lipgloss.Left,
@@ -89,21 +89,18 @@ func renderDeckReviewStats(store *data.Store, focusDeckID string) string {
 	return sb.String()
 }
 
-// formatLastStudied formats the last studied date
-func formatLastStudied(lastDate time.Time) string {
+// formatLastStudied formats the last studied date relative to today
+func formatLastStudied(store *data.Store, lastDate time.Time) string {
 	if lastDate.IsZero() {
 		return "Never"
 	}
 
-	now := time.Now()
-	today := now.Truncate(24 * time.Hour)
-	lastDateDay := lastDate.Truncate(24 * time.Hour)
-
-	if lastDateDay.Equal(today) {
+	switch store.DaysFromToday(lastDate) {
+	case 0:
 		return "Today"
-	} else if lastDateDay.Equal(today.AddDate(0, 0, -1)) {
+	case -1:
 		return "Yesterday"
-	} else {
+	default:
 		return lastDate.Format("Jan 2")
 	}
 }
@@ -135,18 +132,14 @@ func getDeckMatureCards(deck model.Deck) int {
 }
 
 // calculateDeckSuccessRate calculates the percentage of reviews rated 3, 4, or 5 for a specific deck
-func calculateDeckSuccessRate(deck model.Deck) int {
+func calculateDeckSuccessRate(store *data.Store, deck model.Deck) int {
 	var totalReviewed, successful int
 
 	// Get reviews from the last 30 days
-	thirtyDaysAgo := time.Now().AddDate(0, 0, -30)
-
-	for _, card := range deck.Cards {
-		if !card.LastReviewed.IsZero() && card.LastReviewed.After(thirtyDaysAgo) {
-			totalReviewed++
-			if card.Rating >= 3 {
-				successful++
-			}
+	for _, event := range recentDeckHistory(store, deck.ID, 30) {
+		totalReviewed++
+		if event.Rating >= 3 {
+			successful++
 		}
 	}
 
@@ -176,7 +169,7 @@ func calculateDeckAverageInterval(deck model.Deck) float64 {
 }
 
 // calculateDeckRatingDistribution calculates the distribution of ratings (1-5) for a specific deck
-func calculateDeckRatingDistribution(deck model.Deck) map[int]int {
+func calculateDeckRatingDistribution(store *data.Store, deck model.Deck) map[int]int {
 	// Initialize the ratings map
 	distribution := make(map[int]int)
 	for i := 1; i <= 5; i++ {
@@ -184,17 +177,29 @@ func calculateDeckRatingDistribution(deck model.Deck) map[int]int {
 	}
 
 	// Get ratings from the last 30 days
-	thirtyDaysAgo := time.Now().AddDate(0, 0, -30)
-
-	for _, card := range deck.Cards {
-		if !card.LastReviewed.IsZero() && card.LastReviewed.After(thirtyDaysAgo) && card.Rating >= 1 && card.Rating <= 5 {
-			distribution[card.Rating]++
+	for _, event := range recentDeckHistory(store, deck.ID, 30) {
+		if event.Rating >= 1 && event.Rating <= 5 {
+			distribution[event.Rating]++
 		}
 	}
 
 	return distribution
 }
 
+// recentDeckHistory returns the reviews of a deck and its sub-decks made
+// within the last n days
+func recentDeckHistory(store *data.Store, deckID string, days int) []model.ReviewEvent {
+	since := store.Today().AddDate(0, 0, -days)
+
+	var events []model.ReviewEvent
+	for _, event := range store.GetDeckHistoryWithSubDecks(deckID) {
+		if event.Timestamp.After(since) {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
 // renderRatingsDistribution creates a horizontal bar chart for ratings distribution
 func renderRatingsDistribution(distribution map[int]int) string {
 	var sb strings.Builder
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/DavidMiserak/GoCard/internal/clock"
 	"github.com/DavidMiserak/GoCard/internal/data"
 	"github.com/DavidMiserak/GoCard/internal/model"
 )
@@ -43,37 +44,25 @@ func TestGetDeckMatureCards(t *testing.T) {
 }
 
 func TestCalculateDeckSuccessRate(t *testing.T) {
-	// Create a deck with known ratings
-	testDeck := model.Deck{
-		ID:   "test-deck",
-		Name: "Test Deck",
-		Cards: []model.Card{
-			{
-				ID:           "card-1",
-				LastReviewed: time.Now(),
-				Rating:       5, // Success (rating >= 3)
-			},
-			{
-				ID:           "card-2",
-				LastReviewed: time.Now(),
-				Rating:       3, // Success
-			},
-			{
-				ID:           "card-3",
-				LastReviewed: time.Now(),
-				Rating:       2, // Failure
-			},
-			{
-				ID:           "card-4",
-				LastReviewed: time.Now(),
-				Rating:       1, // Failure
-			},
+	// Create a deck with a known review history
+	now := time.Now()
+	testDeck := model.Deck{ID: "test-deck", Name: "Test Deck"}
+	testStore := &data.Store{
+		Decks: []model.Deck{testDeck},
+		History: []model.ReviewEvent{
+			{CardID: "card-1", DeckID: "test-deck", Timestamp: now, Rating: 5}, // Success (rating >= 3)
+			{CardID: "card-2", DeckID: "test-deck", Timestamp: now, Rating: 3}, // Success
+			{CardID: "card-3", DeckID: "test-deck", Timestamp: now, Rating: 2}, // Failure
+			{CardID: "card-4", DeckID: "test-deck", Timestamp: now, Rating: 1}, // Failure
+			// Reviews of other decks and old reviews are ignored
+			{CardID: "card-5", DeckID: "other-deck", Timestamp: now, Rating: 5},
+			{CardID: "card-1", DeckID: "test-deck", Timestamp: now.AddDate(0, 0, -45), Rating: 5},
 		},
 	}
 
 	// Expected success rate: 2 successful out of 4 = 50%
 	expectedRate := 50
-	actualRate := calculateDeckSuccessRate(testDeck)
+	actualRate := calculateDeckSuccessRate(testStore, testDeck)
 
 	if actualRate != expectedRate {
 		t.Errorf("Expected success rate to be %d%%, got %d%%", expectedRate, actualRate)
@@ -109,8 +98,10 @@ func TestCalculateDeckAverageInterval(t *testing.T) {
 }
 
 func TestFormatLastStudied(t *testing.T) {
-	now := time.Now()
+	now := time.Date(2025, 3, 31, 15, 0, 0, 0, time.UTC)
 	today := now.Truncate(24 * time.Hour)
+	store := &data.Store{}
+	store.SetClock(clock.Fixed(now))
 	yesterday := today.AddDate(0, 0, -1)
 	twoDaysAgo := today.AddDate(0, 0, -2)
 
@@ -143,7 +134,7 @@ func TestFormatLastStudied(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			result := formatLastStudied(test.date)
+			result := formatLastStudied(store, test.date)
 			if result != test.expected {
 				t.Errorf("Expected formatLastStudied(%v) to be '%s', got '%s'", test.date, test.expected, result)
 			}
@@ -152,48 +143,31 @@ func TestFormatLastStudied(t *testing.T) {
 }
 
 func TestCalculateDeckRatingDistribution(t *testing.T) {
-	// Create a deck with known ratings
-	testDeck := model.Deck{
-		ID:   "test-deck",
-		Name: "Test Deck",
-		Cards: []model.Card{
-			{
-				ID:           "card-1",
-				LastReviewed: time.Now(),
-				Rating:       1,
-			},
-			{
-				ID:           "card-2",
-				LastReviewed: time.Now(),
-				Rating:       2,
-			},
-			{
-				ID:           "card-3",
-				LastReviewed: time.Now(),
-				Rating:       2, // Another 2
-			},
-			{
-				ID:           "card-4",
-				LastReviewed: time.Now(),
-				Rating:       3,
-			},
-			{
-				ID:           "card-5",
-				LastReviewed: time.Now(),
-				Rating:       4,
-			},
+	// Create a deck with a known review history
+	now := time.Now()
+	testDeck := model.Deck{ID: "test-deck", Name: "Test Deck"}
+	testStore := &data.Store{
+		Decks: []model.Deck{testDeck},
+		History: []model.ReviewEvent{
+			{CardID: "card-1", DeckID: "test-deck", Timestamp: now, Rating: 1},
+			{CardID: "card-2", DeckID: "test-deck", Timestamp: now, Rating: 2},
+			{CardID: "card-3", DeckID: "test-deck", Timestamp: now, Rating: 2}, // Another 2
+			{CardID: "card-4", DeckID: "test-deck", Timestamp: now, Rating: 3},
+			{CardID: "card-5", DeckID: "test-deck", Timestamp: now, Rating: 4},
+			// A review of another deck must not be counted
+			{CardID: "card-6", DeckID: "other-deck", Timestamp: now, Rating: 5},
 		},
 	}
 
-	distribution := calculateDeckRatingDistribution(testDeck)
+	distribution := calculateDeckRatingDistribution(testStore, testDeck)
 
 	// Check each rating count
 	expectedDistribution := map[int]int{
-		1: 1, // One card with rating 1
-		2: 2, // Two cards with rating 2
-		3: 1, // One card with rating 3
-		4: 1, // One card with rating 4
-		5: 0, // No cards with rating 5
+		1: 1, // One review with rating 1
+		2: 2, // Two reviews with rating 2
+		3: 1, // One review with rating 3
+		4: 1, // One review with rating 4
+		5: 0, // No reviews with rating 5
 	}
 
 	for rating, expectedCount := range expectedDistribution {
@@ -266,6 +240,36 @@ func TestRenderDeckReviewStats(t *testing.T) {
 	}
 }
 
+func TestRenderDeckReviewStatsWithSubDecks(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	store := &data.Store{
+		Config: data.DefaultConfig(),
+		Decks: []model.Deck{
+			{ID: "programming", Name: "Programming", Cards: []model.Card{{ID: "p1", DeckID: "programming"}}},
+			{ID: "go", Name: "Go", ParentID: "programming", Cards: []model.Card{
+				{ID: "g1", DeckID: "go", Interval: 30},
+				{ID: "g2", DeckID: "go"},
+			}},
+			{ID: "channels", Name: "Channels", ParentID: "go", Cards: []model.Card{{ID: "c1", DeckID: "channels"}}},
+			{ID: "spanish", Name: "Spanish", Cards: []model.Card{{ID: "s1", DeckID: "spanish"}}},
+		},
+		History: []model.ReviewEvent{
+			{CardID: "g1", DeckID: "go", Timestamp: now, Rating: 4},
+			{CardID: "c1", DeckID: "channels", Timestamp: now, Rating: 1},
+			{CardID: "s1", DeckID: "spanish", Timestamp: now, Rating: 1},
+		},
+	}
+	store.SetClock(clock.Fixed(now))
+
+	// The cards and reviews of the sub-decks count towards the parent deck
+	result := renderDeckReviewStats(store, "programming")
+	for _, expected := range []string{"Deck: Programming", "Total Cards:           4", "Mature Cards:          1", " 50%"} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, result)
+		}
+	}
+}
+
 // Helper function to create a test store
 func createTestStoreForDeckReview() *data.Store {
 	return data.NewStore() // Using the existing NewStore function that creates dummy data
@@ -0,0 +1,68 @@
+// File: internal/ui/editor.go
+
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/DavidMiserak/GoCard/internal/data"
+)
+
+// defaultEditor is run when neither $VISUAL nor $EDITOR is set
+const defaultEditor = "vi"
+
+// cardEditedMsg is sent when the editor opened on a card file exits
+type cardEditedMsg struct {
+	path string
+	err  error // Why the editor could not run or failed
+}
+
+// editorCommand returns the user's editor with its arguments, from $VISUAL
+// or $EDITOR, such as "code --wait"
+func editorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{defaultEditor}
+}
+
+// editCardFile opens a card file in the user's editor. The program is
+// suspended until the editor exits, then a cardEditedMsg is sent.
+func editCardFile(path string) tea.Cmd {
+	editor := editorCommand()
+	cmd := exec.Command(editor[0], append(editor[1:], path)...) //nolint:gosec // The user's own editor
+
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return cardEditedMsg{path: path, err: err}
+	})
+}
+
+// editCard opens the file of a card in the user's editor, or returns a
+// status saying why it cannot be edited
+func editCard(store *data.Store, cardID string) (tea.Cmd, string) {
+	path, ok := store.CardFile(cardID)
+	if !ok {
+		return nil, "This card has no file to edit"
+	}
+	return editCardFile(path), ""
+}
+
+// reloadEditedCard loads a card file again once the editor exits, keeping
+// the card's schedule, and returns a status describing the outcome
+func reloadEditedCard(store *data.Store, msg cardEditedMsg) string {
+	if msg.err != nil {
+		return fmt.Sprintf("Editor failed: %v", msg.err)
+	}
+
+	if err := store.ReloadCard(msg.path); err != nil {
+		return fmt.Sprintf("Card kept as it was: %v", err)
+	}
+	return "Card reloaded"
+}
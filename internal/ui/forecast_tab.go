@@ -4,10 +4,12 @@ package ui
 
 import (
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
 	"github.com/DavidMiserak/GoCard/internal/data"
+	"github.com/DavidMiserak/GoCard/internal/model"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -17,7 +19,7 @@ func renderReviewForecastStats(store *data.Store) string {
 
 	// Get forecast data
 	cardsDueToday := len(store.GetDueCards())
-	cardsDueTomorrow := getCardsDueOnDate(store, time.Now().AddDate(0, 0, 1))
+	cardsDueTomorrow := getCardsDueOnDate(store, store.Today().AddDate(0, 0, 1))
 	cardsDueThisWeek := getCardsDueInNextDays(store, 7)
 	newCardsPerDay := calculateNewCardsPerDay(store)
 	reviewsPerDay := calculateReviewsPerDay(store)
@@ -58,15 +60,14 @@ func renderReviewForecastStats(store *data.Store) string {
 	return sb.String()
 }
 
-// getCardsDueOnDate returns the number of cards due on a specific date
+// getCardsDueOnDate returns the number of cards scheduled on the day containing date
 func getCardsDueOnDate(store *data.Store, date time.Time) int {
 	count := 0
-	startOfDay := date.Truncate(24 * time.Hour)
-	endOfDay := startOfDay.Add(24 * time.Hour)
+	day := store.DaysFromToday(date)
 
 	for _, deck := range store.GetDecks() {
 		for _, card := range deck.Cards {
-			if card.NextReview.After(startOfDay) && card.NextReview.Before(endOfDay) {
+			if !card.NextReview.IsZero() && store.DaysFromToday(card.NextReview) == day {
 				count++
 			}
 		}
@@ -74,15 +75,13 @@ func getCardsDueOnDate(store *data.Store, date time.Time) int {
 	return count
 }
 
-// getCardsDueInNextDays returns the number of cards due in the next n days
+// getCardsDueInNextDays returns the number of cards scheduled on the n days after today
 func getCardsDueInNextDays(store *data.Store, days int) int {
 	count := 0
-	now := time.Now()
-	endDate := now.AddDate(0, 0, days)
 
 	for _, deck := range store.GetDecks() {
 		for _, card := range deck.Cards {
-			if card.NextReview.After(now) && card.NextReview.Before(endDate) {
+			if day := store.DaysFromToday(card.NextReview); day > 0 && day <= days {
 				count++
 			}
 		}
@@ -90,18 +89,51 @@ func getCardsDueInNextDays(store *data.Store, days int) int {
 	return count
 }
 
+// statsWindowDays is the number of days the per-day averages look back
+const statsWindowDays = 30
+
 // calculateNewCardsPerDay returns the average number of new cards studied per day
 func calculateNewCardsPerDay(store *data.Store) int {
-	// In a real implementation, you would analyze the study history
-	// For now, we'll return a fixed number as in the screenshot
-	return 10
+	// A review is a card's first one when no earlier review of it exists
+	seen := make(map[string]bool)
+	var firstReviews []model.ReviewEvent
+
+	for _, event := range store.GetReviewHistory() {
+		if !seen[event.CardID] {
+			seen[event.CardID] = true
+			firstReviews = append(firstReviews, event)
+		}
+	}
+
+	return averagePerDay(store, firstReviews, statsWindowDays)
 }
 
 // calculateReviewsPerDay returns the average number of reviews per day
 func calculateReviewsPerDay(store *data.Store) int {
-	// In a real implementation, you would analyze the study history
-	// For now, we'll return a fixed number as in the screenshot
-	return 32
+	return averagePerDay(store, store.GetReviewHistory(), statsWindowDays)
+}
+
+// averagePerDay returns the average number of events per day over the last
+// n days. The window is shortened to start at the oldest event in it so a
+// collection that is only a few days old is not averaged over a full window.
+func averagePerDay(store *data.Store, events []model.ReviewEvent, days int) int {
+	counts := reviewCountsByDay(store, events, days)
+
+	// Skip leading days before the first review in the window
+	first := 0
+	for first < len(counts) && counts[first] == 0 {
+		first++
+	}
+	if first == len(counts) {
+		return 0
+	}
+
+	total := 0
+	for _, count := range counts[first:] {
+		total += count
+	}
+
+	return int(math.Round(float64(total) / float64(len(counts)-first)))
 }
 
 // ForecastDay represents forecast data for a single day
@@ -113,57 +145,25 @@ type ForecastDay struct {
 
 // generateForecastData generates forecast data for the next n days
 func generateForecastData(store *data.Store, days int) []ForecastDay {
-	now := time.Now()
-	return generateForecastDataFromDate(store, days, now)
+	return generateForecastDataFromDate(store, days, store.Today())
 }
 
 // New helper function with explicit date control for testing
 func generateForecastDataFromDate(store *data.Store, days int, baseDate time.Time) []ForecastDay {
 	forecast := make([]ForecastDay, days)
 
-	// Initialize the forecast days
-	for i := 0; i < days; i++ {
-		date := baseDate.AddDate(0, 0, i)
+	// Fill in the forecast days with the cards due on each
+	for i, counts := range store.DueCountsByDay(baseDate, days) {
 		forecast[i] = ForecastDay{
-			Date:      date,
-			ReviewDue: 0,
-			NewDue:    0,
-		}
-	}
-
-	// Fill in the forecast data
-	for _, deck := range store.GetDecks() {
-		for _, card := range deck.Cards {
-			if card.NextReview.IsZero() {
-				continue
-			}
-
-			// Find which forecast day this card belongs to
-			for i, forecastDay := range forecast {
-				if isSameDay(card.NextReview, forecastDay.Date) {
-					if card.Interval > 0 {
-						// Card has been reviewed before (review card)
-						forecast[i].ReviewDue++
-					} else {
-						// New card
-						forecast[i].NewDue++
-					}
-					break // Card can only be due on one day
-				}
-			}
+			Date:      baseDate.AddDate(0, 0, i),
+			ReviewDue: counts.Reviews,
+			NewDue:    counts.NewCards,
 		}
 	}
 
 	return forecast
 }
 
-// Helper function to check if two dates are the same day
-func isSameDay(date1, date2 time.Time) bool {
-	y1, m1, d1 := date1.Date()
-	y2, m2, d2 := date2.Date()
-	return y1 == y2 && m1 == m2 && d1 == d2
-}
-
 // renderForecastLegend renders the legend for the forecast chart
 func renderForecastLegend() string {
 
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/DavidMiserak/GoCard/internal/clock"
 	"github.com/DavidMiserak/GoCard/internal/data"
 	"github.com/DavidMiserak/GoCard/internal/model"
 )
@@ -14,8 +15,9 @@ import (
 // Forecast Tab Tests
 func TestGetCardsDueOnDate(t *testing.T) {
 	// Create a store with cards due on specific dates
-	tomorrow := time.Now().AddDate(0, 0, 1)
-	dayAfterTomorrow := time.Now().AddDate(0, 0, 2)
+	now := time.Date(2025, 4, 2, 9, 0, 0, 0, time.UTC)
+	tomorrow := now.AddDate(0, 0, 1)
+	dayAfterTomorrow := now.AddDate(0, 0, 2)
 
 	testStore := &data.Store{
 		Decks: []model.Deck{
@@ -38,6 +40,7 @@ func TestGetCardsDueOnDate(t *testing.T) {
 			},
 		},
 	}
+	testStore.SetClock(clock.Fixed(now))
 
 	// Expected: 2 cards due tomorrow
 	expectedCount := 2
@@ -49,7 +52,7 @@ func TestGetCardsDueOnDate(t *testing.T) {
 }
 
 func TestGetCardsDueInNextDays(t *testing.T) {
-	now := time.Now()
+	now := time.Date(2025, 4, 2, 9, 0, 0, 0, time.UTC)
 	tomorrow := now.AddDate(0, 0, 1)
 	threeDaysFromNow := now.AddDate(0, 0, 3)
 	sevenDaysFromNow := now.AddDate(0, 0, 7)
@@ -75,10 +78,15 @@ func TestGetCardsDueInNextDays(t *testing.T) {
 						ID:         "card-4",
 						NextReview: now.AddDate(0, 0, 10), // Outside the 7-day window
 					},
+					{
+						ID:         "card-5",
+						NextReview: now.Add(time.Hour), // Due today, not in the next days
+					},
 				},
 			},
 		},
 	}
+	testStore.SetClock(clock.Fixed(now))
 
 	// Expected: 3 cards due in the next 7 days
 	expectedCount := 3
@@ -92,7 +100,7 @@ func TestGetCardsDueInNextDays(t *testing.T) {
 func TestCalculateNewCardsPerDay(t *testing.T) {
 	store := createTestStoreForForcast()
 
-	// This is a fixed function in the code, so we just check it returns a reasonable value
+	// The dummy data has reviews, so there must be some new cards per day
 	result := calculateNewCardsPerDay(store)
 
 	if result <= 0 {
@@ -103,7 +111,7 @@ func TestCalculateNewCardsPerDay(t *testing.T) {
 func TestCalculateReviewsPerDay(t *testing.T) {
 	store := createTestStoreForForcast()
 
-	// This is a fixed function in the code, so we just check it returns a reasonable value
+	// The dummy data has reviews, so there must be some reviews per day
 	result := calculateReviewsPerDay(store)
 
 	if result <= 0 {
@@ -111,6 +119,42 @@ func TestCalculateReviewsPerDay(t *testing.T) {
 	}
 }
 
+func TestPerDayAveragesFromHistory(t *testing.T) {
+	now := time.Date(2025, 4, 2, 9, 0, 0, 0, time.UTC)
+
+	// Four days of history: card-1 and card-2 are new three days ago,
+	// card-3 is new today, and card-1 is reviewed again yesterday and today
+	testStore := &data.Store{
+		History: []model.ReviewEvent{
+			{CardID: "card-1", Timestamp: now.AddDate(0, 0, -3)},
+			{CardID: "card-2", Timestamp: now.AddDate(0, 0, -3)},
+			{CardID: "card-1", Timestamp: now.AddDate(0, 0, -1)},
+			{CardID: "card-1", Timestamp: now},
+			{CardID: "card-3", Timestamp: now},
+			{CardID: "card-2", Timestamp: now},
+			{CardID: "card-3", Timestamp: now},
+			{CardID: "card-1", Timestamp: now},
+		},
+	}
+	testStore.SetClock(clock.Fixed(now))
+
+	// 3 new cards over 4 days rounds to 1
+	if result := calculateNewCardsPerDay(testStore); result != 1 {
+		t.Errorf("Expected 1 new card per day, got %d", result)
+	}
+
+	// 8 reviews over 4 days
+	if result := calculateReviewsPerDay(testStore); result != 2 {
+		t.Errorf("Expected 2 reviews per day, got %d", result)
+	}
+
+	// Without any history both averages are zero
+	emptyStore := &data.Store{}
+	if calculateNewCardsPerDay(emptyStore) != 0 || calculateReviewsPerDay(emptyStore) != 0 {
+		t.Error("Expected zero averages for a store without history")
+	}
+}
+
 func TestGenerateForecastData(t *testing.T) {
 	// Create a fixed reference date in UTC
 	baseDate := time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC)
@@ -138,6 +182,7 @@ func TestGenerateForecastData(t *testing.T) {
 	}
 
 	// Generate forecast using our fixed UTC base date
+	testStore.SetClock(clock.Fixed(baseDate))
 	forecast := generateForecastDataFromDate(testStore, 3, baseDate)
 
 	// The index for tomorrow should always be 1 (today is 0, tomorrow is 1)
@@ -231,3 +276,34 @@ func TestRenderReviewForecastStats(t *testing.T) {
 func createTestStoreForForcast() *data.Store {
 	return data.NewStore()
 }
+
+func TestForecastDayStartHour(t *testing.T) {
+	// 02:00 is still part of the previous day when days start at 04:00
+	now := time.Date(2025, 4, 2, 2, 0, 0, 0, time.UTC)
+	testStore := &data.Store{
+		Config: data.Config{DayStartHour: 4},
+		Decks: []model.Deck{
+			{
+				ID: "test-deck",
+				Cards: []model.Card{
+					{ID: "card-1", NextReview: now.Add(time.Hour), Interval: 1},     // 03:00, today
+					{ID: "card-2", NextReview: now.Add(3 * time.Hour), Interval: 1}, // 05:00, tomorrow
+				},
+			},
+		},
+	}
+	testStore.SetClock(clock.Fixed(now))
+
+	if due := len(testStore.GetDueCards()); due != 1 {
+		t.Errorf("Expected 1 card due today, got %d", due)
+	}
+
+	if due := getCardsDueOnDate(testStore, testStore.Today().AddDate(0, 0, 1)); due != 1 {
+		t.Errorf("Expected 1 card due tomorrow, got %d", due)
+	}
+
+	forecast := generateForecastData(testStore, 2)
+	if forecast[0].ReviewDue != 1 || forecast[1].ReviewDue != 1 {
+		t.Errorf("Expected one review today and one tomorrow, got %+v", forecast)
+	}
+}
@@ -0,0 +1,62 @@
+// File: internal/ui/leeches_tab.go
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DavidMiserak/GoCard/internal/data"
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+// leechesShown is the number of leeches listed in the Leeches tab
+const leechesShown = 15
+
+// renderLeechesStats renders the Leeches tab: the cards forgotten so often
+// they were tagged as leeches, most lapses first, so they can be rewritten
+func renderLeechesStats(store *data.Store) string {
+	leeches := store.Leeches()
+	if len(leeches) == 0 {
+		return statLabelStyle.Render("No leeches. Cards forgotten too often will be listed here.")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(statLabelStyle.Render(fmt.Sprintf("Leeches: %d", len(leeches))))
+	sb.WriteString("\n\n")
+	sb.WriteString(statLabelStyle.Bold(true).Render(fmt.Sprintf("%-7s %-20s %-40s %s", "Lapses", "Deck", "Question", "Status")))
+	sb.WriteString("\n")
+
+	for _, card := range leeches[:min(len(leeches), leechesShown)] {
+		sb.WriteString(fmt.Sprintf("%6d  %-20s %-40s %s\n",
+			card.Lapses,
+			truncate(deckName(store, card.DeckID), 20),
+			truncate(firstLine(card.Question), 40),
+			leechStatus(card),
+		))
+	}
+
+	if len(leeches) > leechesShown {
+		sb.WriteString(statLabelStyle.Render(fmt.Sprintf("... and %d more", len(leeches)-leechesShown)))
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// leechStatus tells whether a leech is still studied
+func leechStatus(card model.Card) string {
+	if card.Suspended {
+		return "suspended"
+	}
+	return "active"
+}
+
+// firstLine returns the first non-empty line of a text
+func firstLine(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
@@ -0,0 +1,45 @@
+// File: internal/ui/leeches_tab_test.go
+
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DavidMiserak/GoCard/internal/data"
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+func TestRenderLeechesStats(t *testing.T) {
+	store := &data.Store{}
+	if view := renderLeechesStats(store); !strings.Contains(view, "No leeches") {
+		t.Errorf("Expected a message when there are no leeches, got:\n%s", view)
+	}
+
+	store.Decks = []model.Deck{
+		{ID: "chemistry", Name: "Chemistry", Cards: []model.Card{
+			{ID: "a", DeckID: "chemistry", Question: "\nWhat is ozonolysis?\nExplain.", Lapses: 9,
+				Tags: []string{model.LeechTag}, Suspended: true},
+			{ID: "b", DeckID: "chemistry", Question: "What is a buffer?", Lapses: 12, Tags: []string{model.LeechTag}},
+			{ID: "c", DeckID: "chemistry", Question: "What is a mole?", Lapses: 20},
+		}},
+	}
+
+	view := renderLeechesStats(store)
+	if !strings.Contains(view, "Leeches: 2") {
+		t.Errorf("Expected 2 leeches to be counted, got:\n%s", view)
+	}
+	if strings.Contains(view, "mole") {
+		t.Errorf("Expected cards without the leech tag not to be listed, got:\n%s", view)
+	}
+
+	// Most lapses first, with the first line of the question
+	buffer := strings.Index(view, "What is a buffer?")
+	ozonolysis := strings.Index(view, "What is ozonolysis?")
+	if buffer < 0 || ozonolysis < 0 || buffer > ozonolysis {
+		t.Errorf("Expected the leech with most lapses listed first, got:\n%s", view)
+	}
+	if !strings.Contains(view, "suspended") || strings.Contains(view, "Explain.") {
+		t.Errorf("Expected the status and only the first line of questions, got:\n%s", view)
+	}
+}
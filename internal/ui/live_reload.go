@@ -0,0 +1,50 @@
+// File: internal/ui/live_reload.go
+
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/DavidMiserak/GoCard/internal/data"
+)
+
+// FilesChangedMsg is sent when card files of the collection changed on disk
+type FilesChangedMsg struct {
+	Paths []string // Markdown files that were changed, added or removed
+}
+
+// liveReload shows the current screen of the program and loads the files
+// of a FilesChangedMsg into the store before passing it on, so the screen
+// shown can update from the store
+type liveReload struct {
+	screen tea.Model
+	store  *data.Store
+}
+
+// WithLiveReload wraps the first screen of the program so that the card
+// files reported by a FilesChangedMsg are reloaded whatever screen is shown
+func WithLiveReload(screen tea.Model, store *data.Store) tea.Model {
+	return liveReload{screen: screen, store: store}
+}
+
+// Init initializes the current screen
+func (l liveReload) Init() tea.Cmd {
+	return l.screen.Init()
+}
+
+// Update reloads changed files and passes every message on to the current
+// screen, keeping the screen it returns
+func (l liveReload) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if changed, ok := msg.(FilesChangedMsg); ok {
+		l.store.ReloadFiles(changed.Paths)
+	}
+
+	var cmd tea.Cmd
+	l.screen, cmd = l.screen.Update(msg)
+	return l, cmd
+}
+
+// View renders the current screen
+func (l liveReload) View() string {
+	return l.screen.View()
+}
@@ -0,0 +1,77 @@
+// File: internal/ui/live_reload_test.go
+
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/DavidMiserak/GoCard/internal/clock"
+	"github.com/DavidMiserak/GoCard/internal/data"
+)
+
+func TestLiveReload(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+
+	tempDir := t.TempDir()
+	deckDir := filepath.Join(tempDir, "go")
+	if err := os.Mkdir(deckDir, 0755); err != nil {
+		t.Fatalf("Failed to create deck dir: %v", err)
+	}
+	writeCard := func(name, question string) string {
+		path := filepath.Join(deckDir, name)
+		content := "---\ntags: [go]\n---\n# Question\n" + question + "\n# Answer\nA\n"
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write card: %v", err)
+		}
+		return path
+	}
+	cardA := writeCard("a.md", "What is a goroutine?")
+	cardB := writeCard("b.md", "What is a channel?")
+
+	store, err := data.NewStoreFromDirWithClock(tempDir, clock.Fixed(now))
+	if err != nil {
+		t.Fatalf("NewStoreFromDirWithClock error: %v", err)
+	}
+
+	// Studying the deck, the first card is shown
+	var app tea.Model = WithLiveReload(NewStudyScreen(store, deckDir), store)
+	if view := app.View(); !strings.Contains(view, "goroutine") {
+		t.Fatalf("Expected the first card, got:\n%s", app.View())
+	}
+
+	// Editing the shown card updates it, removing it moves on
+	writeCard("a.md", "What is a goroutine, really?")
+	app, _ = app.Update(FilesChangedMsg{Paths: []string{cardA}})
+	if view := app.View(); !strings.Contains(view, "really?") {
+		t.Errorf("Expected the edited question, got:\n%s", view)
+	}
+
+	if err := os.Remove(cardA); err != nil {
+		t.Fatalf("Failed to remove card: %v", err)
+	}
+	app, _ = app.Update(FilesChangedMsg{Paths: []string{cardA}})
+	if view := app.View(); !strings.Contains(view, "channel") {
+		t.Errorf("Expected the next card once the first is removed, got:\n%s", view)
+	}
+
+	// Screens shown later see the changes too
+	app, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	writeCard("c.md", "What is a slice?")
+	app, _ = app.Update(FilesChangedMsg{Paths: []string{filepath.Join(deckDir, "c.md")}})
+	screen, ok := app.(liveReload).screen.(BrowseScreen)
+	if !ok {
+		t.Fatalf("Expected BrowseScreen after back key, got %T", app.(liveReload).screen)
+	}
+	if cards := len(screen.decks[0].deck.Cards); cards != 2 {
+		t.Errorf("Expected 2 cards in the deck, got %d", cards)
+	}
+	if _, found := store.GetCard(cardB); !found {
+		t.Error("Expected the untouched card to stay")
+	}
+}
@@ -0,0 +1,137 @@
+// File: internal/ui/load_errors_screen.go
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/DavidMiserak/GoCard/internal/data"
+)
+
+const (
+	// Number of problems to display per page
+	problemsPerPage = 8
+)
+
+// Key mapping for the load problems screen
+type loadErrorsKeyMap struct {
+	Up   key.Binding
+	Down key.Binding
+	Back key.Binding
+	Quit key.Binding
+}
+
+var loadErrorsKeys = loadErrorsKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("up", "k"), // "k" for Vim users
+		key.WithHelp("↑/k", "scroll up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "j"), // "j" for Vim users
+		key.WithHelp("↓/j", "scroll down"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("b", "esc"),
+		key.WithHelp("b", "back"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("q", "ctrl+c"),
+		key.WithHelp("q", "quit"),
+	),
+}
+
+// LoadErrorsScreen lists the files of the collection that could not be loaded
+type LoadErrorsScreen struct {
+	store  *data.Store
+	offset int // Index of the first problem shown
+	width  int
+	height int
+}
+
+// NewLoadErrorsScreen creates a new load problems screen
+func NewLoadErrorsScreen(store *data.Store) *LoadErrorsScreen {
+	return &LoadErrorsScreen{store: store}
+}
+
+// Init initializes the load problems screen
+func (l LoadErrorsScreen) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles user input and updates the model
+func (l LoadErrorsScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, loadErrorsKeys.Quit):
+			return l, tea.Quit
+
+		case key.Matches(msg, loadErrorsKeys.Up):
+			if l.offset > 0 {
+				l.offset--
+			}
+
+		case key.Matches(msg, loadErrorsKeys.Down):
+			if l.offset < len(l.store.Report.Errors)-problemsPerPage {
+				l.offset++
+			}
+
+		case key.Matches(msg, loadErrorsKeys.Back):
+			// Return to main menu
+			return NewMainMenu(l.store), nil
+		}
+
+	case tea.WindowSizeMsg:
+		l.width = 120 // Default width
+		l.height = msg.Height
+	}
+
+	return l, nil
+}
+
+// View renders the load problems screen
+func (l LoadErrorsScreen) View() string {
+	problems := l.store.Report.Errors
+
+	// Title
+	s := headerStyle.Render("Load Problems")
+	s += "\n\n"
+
+	if len(problems) == 0 {
+		s += normalRowStyle.Render("All files were loaded without problems.")
+		s += "\n\n"
+	} else {
+		s += warningStyle.Render(fmt.Sprintf("Problems found while loading the collection: %d", len(problems)))
+		s += "\n"
+		s += helpStyle.Render("Cards with problems were skipped, other files were read with default values.")
+		s += "\n\n"
+
+		// Display the problems on the current page
+		endIdx := min(l.offset+problemsPerPage, len(problems))
+		for _, problem := range problems[l.offset:endIdx] {
+			location := problem.Path
+			if problem.Line > 0 {
+				location = fmt.Sprintf("%s:%d", problem.Path, problem.Line)
+			}
+			s += normalRowStyle.Render("  " + location)
+			s += "\n"
+			s += problemReasonStyle.Render(strings.TrimSpace(problem.Err.Error()))
+			s += "\n"
+		}
+
+		// Position in the list
+		s += "\n"
+		s += paginationStyle.Render(fmt.Sprintf("%d-%d of %d", l.offset+1, endIdx, len(problems)))
+		s += "\n\n"
+	}
+
+	// Help text
+	help := "\t↑/↓: Scroll" + "\tb: Back" + "\tq: Quit"
+	s += browseHelpStyle.Render(help)
+
+	return s
+}
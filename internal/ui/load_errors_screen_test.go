@@ -0,0 +1,59 @@
+// File: internal/ui/load_errors_screen_test.go
+
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/DavidMiserak/GoCard/internal/data"
+)
+
+func TestLoadErrorsScreen(t *testing.T) {
+	store := data.NewStore()
+	store.Report.Add("deck/bad.md", &data.LoadError{Path: "deck/bad.md", Line: 3, Err: errors.New("error parsing frontmatter")})
+	store.Report.Add("deck/.gocard-history.jsonl", errors.New("error reading review history"))
+
+	// The main menu points out the problems
+	menu := NewMainMenu(store)
+	if view := menu.View(); !strings.Contains(view, "Problems found while loading the collection: 2") {
+		t.Errorf("Expected the main menu to mention the problems, got:\n%s", view)
+	}
+
+	updatedModel, _ := menu.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	screen, ok := updatedModel.(*LoadErrorsScreen)
+	if !ok {
+		t.Fatalf("Expected *LoadErrorsScreen after e key, got %T", updatedModel)
+	}
+
+	// Every problem is listed with its location and reason
+	view := screen.View()
+	for _, expected := range []string{
+		"Load Problems",
+		"deck/bad.md:3",
+		"error parsing frontmatter",
+		"deck/.gocard-history.jsonl",
+		"error reading review history",
+	} {
+		if !strings.Contains(view, expected) {
+			t.Errorf("Expected view to contain %q, got:\n%s", expected, view)
+		}
+	}
+
+	// Back returns to the main menu
+	updatedModel, _ = screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	if _, ok := updatedModel.(*MainMenu); !ok {
+		t.Errorf("Expected *MainMenu after back key, got %T", updatedModel)
+	}
+}
+
+func TestMainMenuWithoutLoadErrors(t *testing.T) {
+	menu := NewMainMenu(data.NewStore())
+
+	if view := menu.View(); strings.Contains(view, "Problems found") {
+		t.Errorf("Expected no problems to be mentioned, got:\n%s", view)
+	}
+}
@@ -3,6 +3,8 @@
 package ui
 
 import (
+	"fmt"
+
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -11,10 +13,11 @@ import (
 
 // Define key mappings
 type keyMap struct {
-	Up    key.Binding
-	Down  key.Binding
-	Enter key.Binding
-	Quit  key.Binding
+	Up     key.Binding
+	Down   key.Binding
+	Enter  key.Binding
+	Errors key.Binding
+	Quit   key.Binding
 }
 
 var keys = keyMap{
@@ -30,6 +33,10 @@ var keys = keyMap{
 		key.WithKeys("enter"),
 		key.WithHelp("enter", "select"),
 	),
+	Errors: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "load problems"),
+	),
 	Quit: key.NewBinding(
 		key.WithKeys("q", "ctrl+c"),
 		key.WithHelp("q", "quit"),
@@ -54,7 +61,7 @@ func NewMainMenu(store *data.Store) *MainMenu {
 	}
 
 	return &MainMenu{
-		items:    []string{"Study", "Browse Decks", "Statistics", "Quit"},
+		items:    []string{"Study", "Study by Tag", "Search", "Browse Decks", "New Card", "Statistics", "Suspended Cards", "Quit"},
 		cursor:   0,
 		selected: -1,
 		store:    store,
@@ -84,24 +91,44 @@ func (m MainMenu) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.cursor++
 			}
 
+		case key.Matches(msg, keys.Errors):
+			// Show the files that could not be loaded
+			return NewLoadErrorsScreen(m.store), nil
+
 		case key.Matches(msg, keys.Enter):
 			m.selected = m.cursor
 
 			// Handle menu selection
 			switch m.cursor {
 			case 0: // Study
-				// Navigate to study screen
-				return NewBrowseScreen(m.store), nil
+				// Study the cards due today in every deck
+				return NewAllDecksStudyScreen(m.store), nil
+
+			case 1: // Study by Tag
+				// Study the cards due today with the tags asked for
+				return NewTagFilterScreen(m.store), nil
+
+			case 2: // Search
+				// Find cards by their text, tags and files
+				return NewSearchScreen(m.store), nil
 
-			case 1: // Browse Decks
+			case 3: // Browse Decks
 				// Navigate to browse decks screen
 				return NewBrowseScreen(m.store), nil
 
-			case 2: // Statistics
+			case 4: // New Card
+				// Write new cards into any deck
+				return NewCardFormScreen(m.store, ""), nil
+
+			case 5: // Statistics
 				// Navigate to statistics screen
 				return NewStatisticsScreen(m.store), nil
 
-			case 3: // Quit
+			case 6: // Suspended Cards
+				// List the suspended and buried cards
+				return NewSetAsideScreen(m.store), nil
+
+			case 7: // Quit
 				return m, tea.Quit
 			}
 		}
@@ -131,8 +158,16 @@ func (m MainMenu) View() string {
 		s += "\n"
 	}
 
+	// Warn about files that could not be loaded
+	help := "\t↑/↓: Navigate" + "\tEnter: Select" + "\tq: Quit"
+	if count := len(m.store.Report.Errors); count > 0 {
+		s += "\n" + warningStyle.Render(fmt.Sprintf("⚠ Problems found while loading the collection: %d", count))
+		s += "\n"
+		help = "\t↑/↓: Navigate" + "\tEnter: Select" + "\te: Show problems" + "\tq: Quit"
+	}
+
 	// Help
-	s += "\n" + helpStyle.Render("\t↑/↓: Navigate"+"\tEnter: Select"+"\tq: Quit")
+	s += "\n" + helpStyle.Render(help)
 
 	return s
 }
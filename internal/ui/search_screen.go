@@ -0,0 +1,419 @@
+// File: internal/ui/search_screen.go
+
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/DavidMiserak/GoCard/internal/data"
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+const (
+	// Number of search results to display at once
+	searchResultsShown = 8
+
+	// Number of lines of the selected card's question previewed
+	searchPreviewLines = 8
+)
+
+// Key mapping for the search screen. Letters are typed into the query, so
+// actions on the results use special keys.
+type searchKeyMap struct {
+	Up    key.Binding
+	Down  key.Binding
+	Open  key.Binding
+	Study key.Binding
+	Edit  key.Binding
+	Back  key.Binding
+	Quit  key.Binding
+}
+
+var searchKeys = searchKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("up", "ctrl+p"),
+		key.WithHelp("↑", "previous result"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "ctrl+n"),
+		key.WithHelp("↓", "next result"),
+	),
+	Open: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "open"),
+	),
+	Study: key.NewBinding(
+		key.WithKeys("ctrl+s"),
+		key.WithHelp("ctrl+s", "study"),
+	),
+	Edit: key.NewBinding(
+		key.WithKeys("ctrl+e"),
+		key.WithHelp("ctrl+e", "edit"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "back"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("ctrl+c"),
+		key.WithHelp("ctrl+c", "quit"),
+	),
+}
+
+// Key mapping for a card opened from the search results
+type searchCardKeyMap struct {
+	Study key.Binding
+	Edit  key.Binding
+	Back  key.Binding
+	Quit  key.Binding
+}
+
+var searchCardKeys = searchCardKeyMap{
+	Study: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "study"),
+	),
+	Edit: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "edit"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("b", "esc"),
+		key.WithHelp("b", "back to results"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("q", "ctrl+c"),
+		key.WithHelp("q", "quit"),
+	),
+}
+
+// SearchScreen finds cards of every deck by their question, answer, tags
+// and file path as the query is typed
+type SearchScreen struct {
+	store            *data.Store
+	index            *data.SearchIndex
+	input            textinput.Model
+	results          []data.SearchResult
+	cursor           int
+	offset           int  // First result shown
+	opened           bool // Showing the selected card in full
+	cardViewport     viewport.Model
+	markdownRenderer *MarkdownRenderer
+	status           string // Outcome of the last action
+	width            int
+	height           int
+}
+
+// NewSearchScreen creates a new search screen
+func NewSearchScreen(store *data.Store) *SearchScreen {
+	input := textinput.New()
+	input.Placeholder = "Search questions, answers, tags and files"
+	input.CharLimit = 200
+	input.Width = 60
+	input.Focus()
+
+	cardViewport := viewport.New(80, 20)
+	cardViewport.Style = viewportStyle
+
+	return &SearchScreen{
+		store:            store,
+		index:            data.NewSearchIndex(store),
+		input:            input,
+		cardViewport:     cardViewport,
+		markdownRenderer: NewMarkdownRenderer(80, "solarized-dark"),
+	}
+}
+
+// Init starts the cursor blinking
+func (s SearchScreen) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update handles user input and updates the model
+func (s SearchScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if s.opened {
+			return s.updateOpened(msg)
+		}
+
+		switch {
+		case key.Matches(msg, searchKeys.Quit):
+			return s, tea.Quit
+
+		case key.Matches(msg, searchKeys.Back):
+			// Return to main menu
+			return NewMainMenu(s.store), nil
+
+		case key.Matches(msg, searchKeys.Up):
+			if s.cursor > 0 {
+				s.cursor--
+			}
+			s.scrollToCursor()
+			return s, nil
+
+		case key.Matches(msg, searchKeys.Down):
+			if s.cursor < len(s.results)-1 {
+				s.cursor++
+			}
+			s.scrollToCursor()
+			return s, nil
+
+		case key.Matches(msg, searchKeys.Open):
+			if card, ok := s.selected(); ok {
+				s.open(card)
+			}
+			return s, nil
+
+		case key.Matches(msg, searchKeys.Study):
+			if card, ok := s.selected(); ok {
+				return NewCardStudyScreen(s.store, card), nil
+			}
+			return s, nil
+
+		case key.Matches(msg, searchKeys.Edit):
+			if card, ok := s.selected(); ok {
+				var cmd tea.Cmd
+				cmd, s.status = editCard(s.store, card.ID)
+				return s, cmd
+			}
+			return s, nil
+		}
+
+	case cardEditedMsg:
+		s.status = reloadEditedCard(s.store, msg)
+		s.reindex()
+		return s, nil
+
+	case FilesChangedMsg:
+		s.reindex()
+		return s, nil
+
+	case tea.WindowSizeMsg:
+		s.width = 120 // Default width
+		s.height = msg.Height
+
+		s.markdownRenderer.UpdateWidth(s.width - 10)
+		s.cardViewport.Width = s.width - 10
+		s.cardViewport.Height = max(s.height-8, 5)
+		if card, ok := s.selected(); ok && s.opened {
+			s.open(card)
+		}
+		return s, nil
+	}
+
+	// Everything else edits the query, searching again as it changes
+	query := s.input.Value()
+	var cmd tea.Cmd
+	s.input, cmd = s.input.Update(msg)
+	if s.input.Value() != query {
+		s.search()
+		s.status = ""
+	}
+	return s, cmd
+}
+
+// updateOpened handles keys while a card is opened in full
+func (s SearchScreen) updateOpened(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	card, _ := s.selected()
+
+	switch {
+	case key.Matches(msg, searchCardKeys.Quit):
+		return s, tea.Quit
+
+	case key.Matches(msg, searchCardKeys.Back):
+		s.opened = false
+
+	case key.Matches(msg, searchCardKeys.Study):
+		return NewCardStudyScreen(s.store, card), nil
+
+	case key.Matches(msg, searchCardKeys.Edit):
+		var cmd tea.Cmd
+		cmd, s.status = editCard(s.store, card.ID)
+		return s, cmd
+
+	default:
+		// Scroll the card
+		var cmd tea.Cmd
+		s.cardViewport, cmd = s.cardViewport.Update(msg)
+		return s, cmd
+	}
+
+	return s, nil
+}
+
+// search finds the cards matching the query and selects the best match
+func (s *SearchScreen) search() {
+	s.results = s.index.Search(s.input.Value())
+	s.cursor = 0
+	s.offset = 0
+}
+
+// reindex indexes the cards of the store again after they changed, keeping
+// the selected card if it still matches
+func (s *SearchScreen) reindex() {
+	selected, hadSelection := s.selected()
+
+	s.index = data.NewSearchIndex(s.store)
+	s.search()
+
+	for i, result := range s.results {
+		if hadSelection && result.Card.ID == selected.ID {
+			s.cursor = i
+			break
+		}
+	}
+	s.scrollToCursor()
+
+	card, ok := s.selected()
+	switch {
+	case s.opened && ok && card.ID == selected.ID:
+		s.open(card) // Show the new version of the card
+	case s.opened:
+		s.opened = false // The card no longer matches
+	}
+}
+
+// selected returns the card of the selected result
+func (s SearchScreen) selected() (model.Card, bool) {
+	if s.cursor < 0 || s.cursor >= len(s.results) {
+		return model.Card{}, false
+	}
+	return s.results[s.cursor].Card, true
+}
+
+// open shows a card in full, with its answer
+func (s *SearchScreen) open(card model.Card) {
+	content := s.markdownRenderer.Render(card.Question) + "\n\n" +
+		answerStyle.Render("Answer") + "\n\n" +
+		s.markdownRenderer.Render(card.Answer)
+	s.cardViewport.SetContent(content)
+	s.cardViewport.GotoTop()
+	s.opened = true
+}
+
+// scrollToCursor keeps the selected result among those shown
+func (s *SearchScreen) scrollToCursor() {
+	if s.cursor < s.offset {
+		s.offset = s.cursor
+	}
+	if s.cursor >= s.offset+searchResultsShown {
+		s.offset = s.cursor - searchResultsShown + 1
+	}
+}
+
+// View renders the search screen
+func (s SearchScreen) View() string {
+	if s.opened {
+		return s.viewOpened()
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(headerStyle.Render("Search"))
+	sb.WriteString("\n\n")
+	sb.WriteString(s.input.View())
+	sb.WriteString("\n\n")
+
+	switch {
+	case strings.TrimSpace(s.input.Value()) == "":
+		sb.WriteString(statLabelStyle.Render(fmt.Sprintf("Type to search %d cards", s.index.Len())))
+		sb.WriteString("\n\n")
+
+	case len(s.results) == 0:
+		sb.WriteString(normalRowStyle.Render("No cards found."))
+		sb.WriteString("\n\n")
+
+	default:
+		found := fmt.Sprintf("%d cards found", len(s.results))
+		if len(s.results) == 1 {
+			found = "1 card found"
+		}
+		sb.WriteString(statLabelStyle.Render(found))
+		sb.WriteString("\n\n")
+
+		// The results around the cursor
+		endIdx := min(s.offset+searchResultsShown, len(s.results))
+		for i := s.offset; i < endIdx; i++ {
+			card := s.results[i].Card
+			row := fmt.Sprintf("%-50s %s",
+				truncate(firstLine(card.Question), 50),
+				truncate(s.cardLocation(card), 40),
+			)
+
+			if i == s.cursor {
+				sb.WriteString(selectedRowStyle.Render(row))
+			} else {
+				sb.WriteString(normalRowStyle.Render(row))
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+
+		// Preview of the selected card's question
+		if card, ok := s.selected(); ok {
+			sb.WriteString(s.preview(card))
+			sb.WriteString("\n\n")
+		}
+	}
+
+	if s.status != "" {
+		sb.WriteString(statLabelStyle.Render(s.status))
+		sb.WriteString("\n\n")
+	}
+
+	// Help text
+	help := "\t↑/↓: Select" + "\tEnter: Open" + "\tCtrl+S: Study" + "\tCtrl+E: Edit" + "\tEsc: Back"
+	sb.WriteString(browseHelpStyle.Render(help))
+
+	return sb.String()
+}
+
+// viewOpened renders the selected card in full
+func (s SearchScreen) viewOpened() string {
+	card, _ := s.selected()
+
+	sb := strings.Builder{}
+	sb.WriteString(headerStyle.Render(s.cardLocation(card)))
+	sb.WriteString("\n\n")
+	if len(card.Tags) > 0 {
+		sb.WriteString(statLabelStyle.Render("Tags: " + strings.Join(card.Tags, ", ")))
+		sb.WriteString("\n\n")
+	}
+	sb.WriteString(s.cardViewport.View())
+	sb.WriteString("\n\n")
+
+	if s.status != "" {
+		sb.WriteString(statLabelStyle.Render(s.status))
+		sb.WriteString("\n\n")
+	}
+
+	help := "\t↑/↓: Scroll" + "\ts: Study" + "\te: Edit" + "\tb: Back to Results" + "\tq: Quit"
+	sb.WriteString(browseHelpStyle.Render(help))
+
+	return sb.String()
+}
+
+// preview renders the first lines of a card's question
+func (s SearchScreen) preview(card model.Card) string {
+	lines := strings.Split(s.markdownRenderer.Render(card.Question), "\n")
+	if len(lines) > searchPreviewLines {
+		lines = append(lines[:searchPreviewLines], "…")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// cardLocation names the deck and file of a card
+func (s SearchScreen) cardLocation(card model.Card) string {
+	name := deckName(s.store, card.DeckID)
+	if _, ok := s.store.CardFile(card.ID); ok {
+		return name + " / " + filepath.Base(card.ID)
+	}
+	return name
+}
@@ -0,0 +1,112 @@
+// File: internal/ui/search_screen_test.go
+
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/DavidMiserak/GoCard/internal/clock"
+	"github.com/DavidMiserak/GoCard/internal/data"
+)
+
+func TestSearchScreen(t *testing.T) {
+	store := newTaggedStore()
+
+	// Search is the third entry of the main menu
+	var m tea.Model = NewMainMenu(store)
+	for i := 0; i < 2; i++ {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if _, ok := m.(*SearchScreen); !ok {
+		t.Fatalf("Expected *SearchScreen after selecting Search, got %T", m)
+	}
+	if view := m.View(); !strings.Contains(view, "Type to search 3 cards") {
+		t.Errorf("Expected view to count the cards to search, got:\n%s", view)
+	}
+
+	// Results update as the query is typed
+	m = typeText(m, "concurrency")
+	if view := m.View(); !strings.Contains(view, "2 cards found") {
+		t.Errorf("Expected 2 cards found, got:\n%s", m.View())
+	}
+	m = typeText(m, " rust")
+	view := m.View()
+	if !strings.Contains(view, "1 card found") || !strings.Contains(view, "threads?") {
+		t.Errorf("Expected only the rust card, got:\n%s", view)
+	}
+
+	// Enter opens the card with its answer
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if view := m.View(); !strings.Contains(view, "Answer") || !strings.Contains(view, "Tags: rust, concurrency") {
+		t.Errorf("Expected the opened card, got:\n%s", view)
+	}
+
+	// Back to the results, then study the card found
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if view := m.View(); !strings.Contains(view, "1 card found") {
+		t.Errorf("Expected the results again, got:\n%s", view)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	study, ok := m.(*StudyScreen)
+	if !ok {
+		t.Fatalf("Expected *StudyScreen after ctrl+s, got %T", m)
+	}
+	if study.totalCards != 1 || study.cards[0].ID != "threads" {
+		t.Errorf("Expected to study the card found, got %d cards", study.totalCards)
+	}
+}
+
+func TestSearchScreenEdit(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+
+	tempDir := t.TempDir()
+	deckDir := filepath.Join(tempDir, "go")
+	if err := os.Mkdir(deckDir, 0755); err != nil {
+		t.Fatalf("Failed to create deck dir: %v", err)
+	}
+	path := filepath.Join(deckDir, "goroutines.md")
+	writeCard := func(question string) {
+		content := "---\ntags: [go]\n---\n# Question\n" + question + "\n# Answer\nA\n"
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write card: %v", err)
+		}
+	}
+	writeCard("What is a goroutine?")
+
+	store, err := data.NewStoreFromDirWithClock(tempDir, clock.Fixed(now))
+	if err != nil {
+		t.Fatalf("NewStoreFromDirWithClock error: %v", err)
+	}
+
+	m := typeText(NewSearchScreen(store), "goroutine")
+	if view := m.View(); !strings.Contains(view, "go / goroutines.md") {
+		t.Errorf("Expected the result to name its deck and file, got:\n%s", view)
+	}
+
+	// Editing a card with a file opens the editor
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlE}); cmd == nil {
+		t.Error("Expected a command opening the editor")
+	}
+
+	// Once the editor exits, the card is loaded again
+	writeCard("What is a goroutine, really?")
+	m, _ = m.Update(cardEditedMsg{path: path})
+	view := m.View()
+	if !strings.Contains(view, "really?") || !strings.Contains(view, "Card reloaded") {
+		t.Errorf("Expected the edited card, got:\n%s", view)
+	}
+
+	// Cards without a file cannot be edited
+	m = typeText(NewSearchScreen(newTaggedStore()), "rust")
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlE})
+	if cmd != nil || !strings.Contains(m.View(), "This card has no file to edit") {
+		t.Errorf("Expected cards without a file not to be edited, got:\n%s", m.View())
+	}
+}
@@ -0,0 +1,194 @@
+// File: internal/ui/set_aside_screen.go
+
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/DavidMiserak/GoCard/internal/data"
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+const (
+	// Number of suspended and buried cards to display per page
+	setAsidePerPage = 10
+)
+
+// Key mapping for the suspended and buried cards screen
+type setAsideKeyMap struct {
+	Up      key.Binding
+	Down    key.Binding
+	Restore key.Binding
+	Back    key.Binding
+	Quit    key.Binding
+}
+
+var setAsideKeys = setAsideKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("up", "k"), // "k" for Vim users
+		key.WithHelp("↑/k", "up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "j"), // "j" for Vim users
+		key.WithHelp("↓/j", "down"),
+	),
+	Restore: key.NewBinding(
+		key.WithKeys("u", "enter"),
+		key.WithHelp("u", "unsuspend"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("b", "esc"),
+		key.WithHelp("b", "back"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("q", "ctrl+c"),
+		key.WithHelp("q", "quit"),
+	),
+}
+
+// SetAsideScreen lists the suspended and buried cards and puts them back
+// into study sessions
+type SetAsideScreen struct {
+	store  *data.Store
+	cards  []model.Card
+	cursor int
+	status string // Outcome of the last action
+	width  int
+	height int
+}
+
+// NewSetAsideScreen creates a new suspended and buried cards screen
+func NewSetAsideScreen(store *data.Store) *SetAsideScreen {
+	return &SetAsideScreen{
+		store: store,
+		cards: store.SetAsideCards(),
+	}
+}
+
+// Init initializes the suspended and buried cards screen
+func (a SetAsideScreen) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles user input and updates the model
+func (a SetAsideScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, setAsideKeys.Quit):
+			return a, tea.Quit
+
+		case key.Matches(msg, setAsideKeys.Up):
+			if a.cursor > 0 {
+				a.cursor--
+			}
+
+		case key.Matches(msg, setAsideKeys.Down):
+			if a.cursor < len(a.cards)-1 {
+				a.cursor++
+			}
+
+		case key.Matches(msg, setAsideKeys.Restore):
+			if len(a.cards) > 0 {
+				a.restore(a.cards[a.cursor])
+			}
+
+		case key.Matches(msg, setAsideKeys.Back):
+			// Return to main menu
+			return NewMainMenu(a.store), nil
+		}
+
+	case FilesChangedMsg:
+		a.refresh()
+
+	case tea.WindowSizeMsg:
+		a.width = 120 // Default width
+		a.height = msg.Height
+	}
+
+	return a, nil
+}
+
+// restore unsuspends and unburies a card and refreshes the list
+func (a *SetAsideScreen) restore(card model.Card) {
+	var err error
+	if card.Suspended {
+		err = a.store.UnsuspendCard(card.ID)
+	}
+	if err == nil && a.store.IsBuried(card) {
+		err = a.store.UnburyCard(card.ID)
+	}
+
+	if err != nil {
+		a.status = fmt.Sprintf("Error: %v", err)
+		return
+	}
+	a.status = "Back in study sessions: " + truncate(firstLine(card.Question), 40)
+	a.refresh()
+}
+
+// refresh lists the cards set aside in the store again
+func (a *SetAsideScreen) refresh() {
+	a.cards = a.store.SetAsideCards()
+	a.cursor = max(min(a.cursor, len(a.cards)-1), 0)
+}
+
+// View renders the suspended and buried cards screen
+func (a SetAsideScreen) View() string {
+	s := headerStyle.Render("Suspended & Buried Cards")
+	s += "\n\n"
+
+	if len(a.cards) == 0 {
+		s += normalRowStyle.Render("No cards are suspended or buried.")
+		s += "\n\n"
+	} else {
+		// Table header
+		s += fmt.Sprintf("%-26s %-20s %s\n", "Status", "Deck", "Question")
+
+		// Display the cards on the page of the cursor
+		startIdx := a.cursor / setAsidePerPage * setAsidePerPage
+		endIdx := min(startIdx+setAsidePerPage, len(a.cards))
+		for i := startIdx; i < endIdx; i++ {
+			card := a.cards[i]
+			row := fmt.Sprintf("%-26s %-20s %s",
+				a.setAsideStatus(card),
+				truncate(deckName(a.store, card.DeckID), 20),
+				truncate(firstLine(card.Question), 40),
+			)
+
+			if i == a.cursor {
+				s += selectedRowStyle.Render(row)
+			} else {
+				s += normalRowStyle.Render(row)
+			}
+			s += "\n"
+		}
+
+		// Position in the list
+		s += "\n"
+		s += paginationStyle.Render(fmt.Sprintf("%d of %d", a.cursor+1, len(a.cards)))
+		s += "\n\n"
+	}
+
+	if a.status != "" {
+		s += statLabelStyle.Render(a.status)
+		s += "\n\n"
+	}
+
+	// Help text
+	help := "\t↑/↓: Navigate" + "\tu: Unsuspend" + "\tb: Back" + "\tq: Quit"
+	s += browseHelpStyle.Render(help)
+
+	return s
+}
+
+// setAsideStatus tells why a card is out of study sessions
+func (a SetAsideScreen) setAsideStatus(card model.Card) string {
+	if card.Suspended {
+		return "Suspended"
+	}
+	return "Buried until " + card.BuriedUntil.In(a.store.Now().Location()).Format("Jan 2 15:04")
+}
@@ -0,0 +1,78 @@
+// File: internal/ui/set_aside_screen_test.go
+
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/DavidMiserak/GoCard/internal/clock"
+	"github.com/DavidMiserak/GoCard/internal/data"
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+func TestSetAsideScreen(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	store := &data.Store{
+		Config: data.DefaultConfig(),
+		Decks: []model.Deck{
+			{ID: "deck", Name: "Go", Cards: []model.Card{
+				{ID: "a", DeckID: "deck", Question: "What is a goroutine?", NextReview: now, Suspended: true},
+				{ID: "b", DeckID: "deck", Question: "What is a channel?", NextReview: now, BuriedUntil: now.AddDate(0, 0, 1)},
+				{ID: "c", DeckID: "deck", Question: "What is a slice?", NextReview: now},
+			}},
+		},
+	}
+	store.SetClock(clock.Fixed(now))
+
+	// The main menu opens the list of suspended and buried cards
+	menu := NewMainMenu(store)
+	var updatedModel tea.Model = menu
+	for i := 0; i < 6; i++ {
+		updatedModel, _ = updatedModel.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	updatedModel, _ = updatedModel.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	screen, ok := updatedModel.(*SetAsideScreen)
+	if !ok {
+		t.Fatalf("Expected *SetAsideScreen after selecting Suspended Cards, got %T", updatedModel)
+	}
+
+	view := screen.View()
+	for _, expected := range []string{"Suspended", "Buried until Apr 1", "What is a goroutine?", "What is a channel?", "Go"} {
+		if !strings.Contains(view, expected) {
+			t.Errorf("Expected view to contain %q, got:\n%s", expected, view)
+		}
+	}
+	if strings.Contains(view, "What is a slice?") {
+		t.Errorf("Expected cards in study to be left out, got:\n%s", view)
+	}
+
+	// Unsuspending puts the card back in study and off the list
+	updatedModel, _ = screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	updatedScreen := updatedModel.(SetAsideScreen)
+	if card, _ := store.GetCard("a"); card.Suspended {
+		t.Errorf("Expected card a to be unsuspended")
+	}
+	if len(updatedScreen.cards) != 1 || updatedScreen.cursor != 0 {
+		t.Fatalf("Expected one card left with the cursor on it, got %d cards at %d", len(updatedScreen.cards), updatedScreen.cursor)
+	}
+
+	// Unburying works the same way
+	updatedModel, _ = updatedScreen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	updatedScreen = updatedModel.(SetAsideScreen)
+	if due := store.GetDueCardsForDeck("deck"); len(due) != 3 {
+		t.Errorf("Expected all 3 cards due again, got %d", len(due))
+	}
+	if view := updatedScreen.View(); !strings.Contains(view, "No cards are suspended or buried.") {
+		t.Errorf("Expected an empty list, got:\n%s", view)
+	}
+
+	// Back returns to the main menu
+	updatedModel, _ = updatedScreen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	if _, ok := updatedModel.(*MainMenu); !ok {
+		t.Errorf("Expected *MainMenu after back key, got %T", updatedModel)
+	}
+}
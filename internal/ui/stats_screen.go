@@ -10,6 +10,9 @@ import (
 	"github.com/DavidMiserak/GoCard/internal/data"
 )
 
+// statsTabs are the names of the tabs of the statistics screen
+var statsTabs = []string{"Summary", "Deck Review", "Review Forecast", "Leeches"}
+
 // StatisticsScreen represents the statistics view
 type StatisticsScreen struct {
 	store      *data.Store
@@ -42,9 +45,7 @@ func NewStatisticsScreenWithDeck(store *data.Store, deckID string) *StatisticsSc
 
 // calculateCardStudiedPerDay calculates cards studied per day for the last 5 days
 func calculateCardStudiedPerDay(store *data.Store) []int {
-	// This is a placeholder implementation
-	// In a real app, you'd track actual study history
-	return []int{35, 15, 25, 40, 20}
+	return reviewCountsByDay(store, store.GetReviewHistory(), 5)
 }
 
 // Init initializes the statistics screen
@@ -64,9 +65,12 @@ func (s *StatisticsScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return NewMainMenu(s.store), nil
 		case "tab":
 			// Cycle through tabs
-			s.activeTab = (s.activeTab + 1) % 3
+			s.activeTab = (s.activeTab + 1) % len(statsTabs)
 		}
 
+	case FilesChangedMsg:
+		s.cardStats = calculateCardStudiedPerDay(s.store)
+
 	case tea.WindowSizeMsg:
 		s.width = 120 // Default width
 		s.height = msg.Height
@@ -84,9 +88,8 @@ func (s *StatisticsScreen) View() string {
 	sb.WriteString("\n\n")
 
 	// Tabs
-	tabs := []string{"Summary", "Deck Review", "Review Forecast"}
 	tabRow := ""
-	for i, tab := range tabs {
+	for i, tab := range statsTabs {
 		if i == s.activeTab {
 			tabRow += activeTabStyle.Render(tab) + " "
 		} else {
@@ -106,6 +109,8 @@ func (s *StatisticsScreen) View() string {
 		sb.WriteString(renderDeckReviewStats(s.store, s.lastDeckID))
 	case 2:
 		sb.WriteString(renderReviewForecastStats(s.store))
+	case 3:
+		sb.WriteString(renderLeechesStats(s.store))
 	}
 
 	sb.WriteString("\n\n")
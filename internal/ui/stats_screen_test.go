@@ -91,12 +91,20 @@ func TestStatisticsScreenUpdate(t *testing.T) {
 		t.Error("Expected cmd to be nil")
 	}
 
+	// Then the Leeches tab
+	model, _ = updatedScreen.Update(tea.KeyMsg{Type: tea.KeyTab})
+	updatedScreen = model.(*StatisticsScreen)
+
+	if updatedScreen.activeTab != 3 {
+		t.Errorf("Expected activeTab to be 3 after third Tab key, got %d", updatedScreen.activeTab)
+	}
+
 	// Test cycling back to 0
 	model, _ = updatedScreen.Update(tea.KeyMsg{Type: tea.KeyTab})
 	updatedScreen = model.(*StatisticsScreen)
 
 	if updatedScreen.activeTab != 0 {
-		t.Errorf("Expected activeTab to be 0 after second Tab key, got %d", updatedScreen.activeTab)
+		t.Errorf("Expected activeTab to be 0 after third Tab key, got %d", updatedScreen.activeTab)
 	}
 
 	// Test back to main menu
@@ -5,6 +5,7 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
@@ -19,6 +20,11 @@ type studyKeyMap struct {
 	ShowAnswer key.Binding
 	Skip       key.Binding
 	Back       key.Binding
+	StudyAhead key.Binding
+	Suspend    key.Binding
+	Bury       key.Binding
+	Undo       key.Binding
+	Edit       key.Binding
 	Quit       key.Binding
 	Rate1      key.Binding // Blackout
 	Rate2      key.Binding // Wrong
@@ -40,6 +46,26 @@ var studyKeys = studyKeyMap{
 		key.WithKeys("b"),
 		key.WithHelp("b", "Back to Decks"),
 	),
+	StudyAhead: key.NewBinding(
+		key.WithKeys("y"),
+		key.WithHelp("y", "Study Ahead"),
+	),
+	Suspend: key.NewBinding(
+		key.WithKeys("@"),
+		key.WithHelp("@", "Suspend"),
+	),
+	Bury: key.NewBinding(
+		key.WithKeys("-"),
+		key.WithHelp("-", "Bury"),
+	),
+	Undo: key.NewBinding(
+		key.WithKeys("u", "ctrl+z"),
+		key.WithHelp("u", "Undo"),
+	),
+	Edit: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "Edit"),
+	),
 	Quit: key.NewBinding(
 		key.WithKeys("q", "ctrl+c"),
 		key.WithHelp("q", "Quit"),
@@ -73,36 +99,91 @@ const (
 	ShowingQuestion StudyState = iota
 	ShowingAnswer
 	FinishedStudying
+	NothingDue     // No cards left to study today, offering to study ahead
+	WaitingForCard // Only cards in learning are left, waiting for their step to end
 )
 
+// ratedCard is a rating made in the session, kept so it can be undone
+type ratedCard struct {
+	index      int        // Position of the card in the session
+	card       model.Card // The card as it was before the rating
+	totalCards int        // Number of cards in the session before the rating
+}
+
+// learningDueMsg is sent when the step of a card in learning may be over
+type learningDueMsg struct{}
+
 // StudyScreen represents the screen for studying flashcards
 type StudyScreen struct {
 	store            *data.Store
-	deckID           string
+	deckID           string          // Empty when studying all decks
+	filter           *data.TagFilter // Tags of the cards studied across decks, if any
 	deck             model.Deck
 	cards            []model.Card
 	cardIndex        int
 	totalCards       int
-	studiedCards     map[int]bool // Track which cards have been studied
+	studiedCards     map[int]bool      // Track which cards have been studied
+	dueAt            map[int]time.Time // When cards in learning shown again this session are due
+	rated            []ratedCard       // Ratings that can be undone, latest last
 	state            StudyState
 	width            int
 	height           int
 	markdownRenderer *MarkdownRenderer
 	answerViewport   viewport.Model
+	questionShownAt  time.Time // When the current question was first shown
+	status           string    // Outcome of the last action, shown above the help
 }
 
 // NewStudyScreen creates a new study screen for the specified deck
 func NewStudyScreen(store *data.Store, deckID string) *StudyScreen {
-	// Get the deck from the store
-	deck, found := store.GetDeck(deckID)
+	// Get the deck from the store, together with the cards of its sub-decks
+	deck, found := store.GetDeckWithSubDecks(deckID)
 	if !found {
 		// If the deck is not found, return to the browse screen
 		// This should not happen in normal operation but is a safeguard
 		return nil
 	}
 
-	// Get the cards from the deck
-	cards := deck.Cards
+	// Study the cards due today, within the daily limits
+	return newStudyScreen(store, deckID, deck, store.StudyQueue(deckID))
+}
+
+// NewAllDecksStudyScreen creates a study screen for the cards due today in
+// every deck, interleaved as configured
+func NewAllDecksStudyScreen(store *data.Store) *StudyScreen {
+	deck := model.Deck{Name: "All Decks"}
+	for _, d := range store.GetDecks() {
+		deck.Cards = append(deck.Cards, d.Cards...)
+	}
+
+	return newStudyScreen(store, "", deck, store.AllDecksStudyQueue())
+}
+
+// NewTagStudyScreen creates a study screen for the cards due today in every
+// deck that match a tag filter
+func NewTagStudyScreen(store *data.Store, filter data.TagFilter) *StudyScreen {
+	deck := model.Deck{Name: "Tags: " + filter.String(), Cards: store.TaggedCards(filter)}
+
+	s := newStudyScreen(store, "", deck, store.TagStudyQueue(filter))
+	s.filter = &filter
+	return s
+}
+
+// NewCardStudyScreen creates a study screen for a single card, due or not,
+// such as one found by a search
+func NewCardStudyScreen(store *data.Store, card model.Card) *StudyScreen {
+	deck, _ := store.GetDeck(card.DeckID)
+	deck.Cards = []model.Card{card}
+
+	return newStudyScreen(store, card.DeckID, deck, []model.Card{card})
+}
+
+// newStudyScreen creates a study screen going through the given cards of a deck
+func newStudyScreen(store *data.Store, deckID string, deck model.Deck, cards []model.Card) *StudyScreen {
+	state := ShowingQuestion
+	if len(cards) == 0 && len(deck.Cards) > 0 {
+		state = NothingDue
+	}
 
 	// Initialize markdown renderer with default width (will be updated on resize)
 	mdRenderer := NewMarkdownRenderer(80, "solarized-dark")
@@ -119,9 +200,11 @@ func NewStudyScreen(store *data.Store, deckID string) *StudyScreen {
 		cardIndex:        0,
 		totalCards:       len(cards),
 		studiedCards:     make(map[int]bool), // Initialize the map to track studied cards
-		state:            ShowingQuestion,
+		dueAt:            make(map[int]time.Time),
+		state:            state,
 		markdownRenderer: mdRenderer,
 		answerViewport:   answerViewport,
+		questionShownAt:  time.Now(),
 	}
 }
 
@@ -135,26 +218,71 @@ func (s *StudyScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
+	case learningDueMsg:
+		if s.state == WaitingForCard {
+			return s, s.waitForCard()
+		}
+
+	case FilesChangedMsg:
+		return s, s.reloadCards()
+
+	case cardEditedMsg:
+		status := reloadEditedCard(s.store, msg)
+		cmd = s.reloadCards()
+		s.status = status
+		s.questionShownAt = time.Now() // Time in the editor is not answering time
+		return s, cmd
+
 	case tea.KeyMsg:
+		// Undo the latest rating, even once the session is finished
+		if key.Matches(msg, studyKeys.Undo) && len(s.rated) > 0 {
+			s.undoRating()
+			return s, nil
+		}
+
 		// If in finished state, any key navigates to stats screen
 		if s.state == FinishedStudying {
-			// Only try to save markdown if this isn't a dummy deck
-			if strings.Contains(s.deckID, "/") || strings.Contains(s.deckID, "\\") {
-				if err := s.store.SaveDeckToMarkdown(s.deckID); err != nil {
-					// Log the error but continue
-					fmt.Printf("Error saving deck to markdown: %v\n", err)
-				}
+			return NewStatisticsScreenWithDeck(s.store, s.deckID), nil
+		}
+
+		// Nothing is due: study ahead or go back to the decks
+		if s.state == NothingDue {
+			switch {
+			case key.Matches(msg, studyKeys.Quit):
+				return s, tea.Quit
+			case key.Matches(msg, studyKeys.StudyAhead) && len(s.studyAheadCards()) > 0:
+				s.studyAhead()
+				return s, nil
+			default:
+				return NewBrowseScreen(s.store), nil
 			}
+		}
 
-			return NewStatisticsScreenWithDeck(s.store, s.deckID), nil
+		// Without cards to study, only going back is left
+		if s.totalCards == 0 {
+			switch {
+			case key.Matches(msg, studyKeys.Quit):
+				return s, tea.Quit
+			case key.Matches(msg, studyKeys.Back):
+				return NewBrowseScreen(s.store), nil
+			}
+			return s, nil
+		}
+
+		// Study the waiting card before its step is over
+		if msg.Type == tea.KeySpace && s.state == WaitingForCard {
+			s.showQuestion()
+			return s, nil
 		}
 
 		// Handle space key explicitly since it's special in Bubble Tea
 		if msg.Type == tea.KeySpace && s.state == ShowingQuestion {
-			s.state = ShowingAnswer
-
 			// Prepare viewport for the current card's answer
-			currentCard := s.cards[s.cardIndex]
+			currentCard, ok := s.currentCard()
+			if !ok {
+				return s, nil
+			}
+			s.state = ShowingAnswer
 			renderedAnswer := s.markdownRenderer.Render(currentCard.Answer)
 			s.answerViewport.SetContent(renderedAnswer)
 			s.answerViewport.GotoTop()
@@ -173,8 +301,20 @@ func (s *StudyScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case key.Matches(msg, studyKeys.Skip):
 			// Skip this card and go to the next one
-			s.nextCard()
-			return s, nil
+			return s, s.nextCard()
+
+		case key.Matches(msg, studyKeys.Suspend):
+			return s, s.setAside(s.store.SuspendCard, "suspended")
+
+		case key.Matches(msg, studyKeys.Bury):
+			return s, s.setAside(s.store.BuryCard, "buried until tomorrow")
+
+		case key.Matches(msg, studyKeys.Edit):
+			// Fix the card in the user's editor
+			if currentCard, ok := s.currentCard(); ok {
+				cmd, s.status = editCard(s.store, currentCard.ID)
+			}
+			return s, cmd
 		}
 
 		// Handle viewport scrolling and rating keys when showing the answer
@@ -196,32 +336,35 @@ func (s *StudyScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 			// Check if the key pressed is a number between 1-5 for ratings
-			if msg.Type == tea.KeyRunes && len(msg.Runes) == 1 {
+			if currentCard, ok := s.currentCard(); ok && msg.Type == tea.KeyRunes && len(msg.Runes) == 1 {
 				r := msg.Runes[0]
 				if r >= '1' && r <= '5' {
 					// Convert rune to integer rating (1-5)
 					rating := int(r - '0')
 
-					// Get the current card
-					currentCard := s.cards[s.cardIndex]
+					s.rated = append(s.rated, ratedCard{index: s.cardIndex, card: currentCard, totalCards: s.totalCards})
 
-					// Save the card review with the given rating
-					success := s.store.SaveCardReview(currentCard, rating)
+					// Save the card review with the given rating and answer time
+					timeToAnswer := time.Since(s.questionShownAt)
+					err := s.store.SaveCardReviewWithTime(currentCard, rating, timeToAnswer)
 
-					// If the update was successful, update our local cards array
-					// to reflect the changes (important for the UI to show correct data)
-					if success {
-						updatedDeck, _ := s.store.GetDeck(s.deckID)
-						s.deck = updatedDeck
-						s.cards = updatedDeck.Cards
+					// Update our local copy of the card to reflect the changes
+					// (important for the UI to show correct data). The review is
+					// in the store even if it could not be written to disk.
+					if updatedCard, found := s.store.GetCard(currentCard.ID); found {
+						s.cards[s.cardIndex] = updatedCard
+						s.requeueLearningCard(updatedCard)
 					}
 
 					// Mark the current card as studied
 					s.studiedCards[s.cardIndex] = true
 
-					// Move to the next card
-					s.nextCard()
-					return s, nil
+					// Move to the next card, telling if the review was not saved
+					cmd = s.nextCard()
+					if err != nil {
+						s.status = fmt.Sprintf("Error: %v", err)
+					}
+					return s, cmd
 				}
 			}
 		}
@@ -245,38 +388,200 @@ func (s *StudyScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return s, cmd
 }
 
-// nextCard advances to the next card or transitions to FinishedStudying state
-// if all cards have been studied
-func (s *StudyScreen) nextCard() {
+// studyAheadCards returns the cards that are not due yet, the ones due
+// soonest first
+func (s *StudyScreen) studyAheadCards() []model.Card {
+	switch {
+	case s.filter != nil:
+		return s.store.TagStudyAheadCards(*s.filter)
+	case s.deckID == "":
+		return s.store.AllDecksStudyAheadCards()
+	default:
+		return s.store.StudyAheadCards(s.deckID)
+	}
+}
+
+// studyAhead starts a session with the cards that are not due yet. Nothing
+// changes when there are none, as when every card is suspended.
+func (s *StudyScreen) studyAhead() {
+	cards := s.studyAheadCards()
+	if len(cards) == 0 {
+		return
+	}
+	s.cards = cards
+	s.totalCards = len(s.cards)
+	s.cardIndex = 0
+	s.studiedCards = make(map[int]bool)
+	s.dueAt = make(map[int]time.Time)
+	s.rated = nil
+	s.showQuestion()
+}
+
+// setAside takes the current card out of the session with the given store
+// action, suspending or burying it, and moves on to the next card
+func (s *StudyScreen) setAside(action func(cardID string) error, done string) tea.Cmd {
+	current, ok := s.currentCard()
+	if !ok {
+		return nil
+	}
+	cardID := current.ID
+	if err := action(cardID); err != nil {
+		s.status = fmt.Sprintf("Error: %v", err)
+		return nil
+	}
+
+	// Drop the card from the session, including a copy waiting in learning
+	for i, card := range s.cards {
+		if card.ID == cardID {
+			s.studiedCards[i] = true
+		}
+	}
+	cmd := s.nextCard()
+	s.status = "Card " + done
+	return cmd
+}
+
+// undoRating puts the card of the latest rating back the way it was before
+// it, in the store and in the session, and shows it again
+func (s *StudyScreen) undoRating() {
+	last := s.rated[len(s.rated)-1]
+	if err := s.store.UndoCardReview(last.card); err != nil {
+		s.status = fmt.Sprintf("Error: %v", err)
+		return
+	}
+	s.rated = s.rated[:len(s.rated)-1]
+
+	// Drop the copy of the card the rating requeued in learning
+	for i := last.totalCards; i < s.totalCards; i++ {
+		delete(s.dueAt, i)
+		delete(s.studiedCards, i)
+	}
+	s.cards = s.cards[:last.totalCards]
+	s.totalCards = last.totalCards
+
+	s.cards[last.index] = last.card
+	delete(s.studiedCards, last.index)
+	s.cardIndex = last.index
+	s.showQuestion()
+	s.status = "Rating undone"
+}
+
+// reloadCards brings the cards of the session up to date after their files
+// changed on disk. Cards whose file is gone are dropped from the session.
+func (s *StudyScreen) reloadCards() tea.Cmd {
+	for i, card := range s.cards {
+		updated, found := s.store.GetCard(card.ID)
+		switch {
+		case !found:
+			s.studiedCards[i] = true
+		case !s.studiedCards[i]:
+			s.cards[i] = updated
+		}
+	}
+
+	current, ok := s.currentCard()
+	if !ok {
+		return nil
+	}
+	switch s.state {
+	case ShowingQuestion, ShowingAnswer, WaitingForCard:
+		if s.studiedCards[s.cardIndex] {
+			// The current card was removed
+			return s.nextCard()
+		}
+		if s.state == ShowingAnswer {
+			s.answerViewport.SetContent(s.markdownRenderer.Render(current.Answer))
+		}
+	}
+	return nil
+}
+
+// requeueLearningCard adds a card that is still in learning to the end of
+// the session, to be shown again once its step is over. Steps that end on a
+// later day are left for that day's queue.
+func (s *StudyScreen) requeueLearningCard(card model.Card) {
+	if !card.IsLearning() || s.store.DaysFromToday(card.NextReview) > 0 {
+		return
+	}
+
+	s.cards = append(s.cards, card)
+	s.dueAt[s.totalCards] = card.NextReview
+	s.totalCards++
+}
+
+// nextCard advances to the next unstudied card that is due. Cards in
+// learning whose step is not over yet are skipped; when only those are left
+// the screen waits for the first of them. Once all cards have been studied
+// it transitions to the FinishedStudying state.
+func (s *StudyScreen) nextCard() tea.Cmd {
 	// Check if we've studied all cards
 	if len(s.studiedCards) >= s.totalCards {
 		s.state = FinishedStudying
-		return
+		return nil
 	}
 
-	// Find the next unstudied card
-	originalIndex := s.cardIndex
-	for {
-		s.cardIndex = (s.cardIndex + 1) % s.totalCards
+	// Find the next unstudied card, coming back to the current one last
+	now := s.now()
+	waiting := -1
+	for offset := 1; offset <= s.totalCards; offset++ {
+		index := (s.cardIndex + offset) % s.totalCards
+		if s.studiedCards[index] {
+			continue
+		}
 
-		// If we've cycled through all cards and returned to our starting point,
-		// it means there are no unstudied cards left
-		if s.cardIndex == originalIndex {
-			// Check if the current card is also studied
-			if s.studiedCards[s.cardIndex] {
-				s.state = FinishedStudying
-				return
+		if dueAt, ok := s.dueAt[index]; ok && dueAt.After(now) {
+			if waiting < 0 || dueAt.Before(s.dueAt[waiting]) {
+				waiting = index
 			}
-			break
+			continue
 		}
 
-		// If this card hasn't been studied yet, break the loop
-		if !s.studiedCards[s.cardIndex] {
-			break
-		}
+		s.cardIndex = index
+		s.showQuestion()
+		return nil
 	}
 
+	// Only cards in learning are left
+	s.cardIndex = waiting
+	s.state = WaitingForCard
+	return s.waitForCard()
+}
+
+// waitForCard shows the waiting card once its step is over, or returns a
+// command that checks again when it should be
+func (s *StudyScreen) waitForCard() tea.Cmd {
+	wait := s.dueAt[s.cardIndex].Sub(s.now())
+	if wait <= 0 {
+		s.showQuestion()
+		return nil
+	}
+
+	return tea.Tick(wait, func(time.Time) tea.Msg {
+		return learningDueMsg{}
+	})
+}
+
+// currentCard returns the card being studied, if the session has any
+func (s *StudyScreen) currentCard() (model.Card, bool) {
+	if s.cardIndex < 0 || s.cardIndex >= len(s.cards) {
+		return model.Card{}, false
+	}
+	return s.cards[s.cardIndex], true
+}
+
+// showQuestion shows the question of the current card
+func (s *StudyScreen) showQuestion() {
 	s.state = ShowingQuestion
+	s.questionShownAt = time.Now()
+	s.status = ""
+}
+
+// now returns the current time of the store
+func (s *StudyScreen) now() time.Time {
+	if s.store == nil {
+		return time.Now()
+	}
+	return s.store.Now()
 }
 
 // renderProgressBar renders a progress bar showing the current card position
@@ -312,6 +617,27 @@ func (s *StudyScreen) renderProgressBar() string {
 func (s *StudyScreen) View() string {
 	var sb strings.Builder
 
+	// Offer to study ahead when no cards are left for today
+	if s.state == NothingDue {
+		sb.WriteString(studyTitleStyle.Render(fmt.Sprintf("Studying: %s", s.deck.Name)))
+		sb.WriteString("\n\n")
+		sb.WriteString(fmt.Sprintf("Nothing due in %s today.", s.deckDescription()))
+		sb.WriteString("\n\n")
+
+		// Suspended and buried cards cannot be studied ahead
+		if len(s.studyAheadCards()) == 0 {
+			sb.WriteString("Nothing to study ahead either.")
+			sb.WriteString("\n\n")
+			sb.WriteString(studyHelpStyle.Render("\tAny key: Back to Decks" + "\tq: Quit"))
+			return sb.String()
+		}
+
+		sb.WriteString(revealPromptStyle.Render("Study ahead? (y/n)"))
+		sb.WriteString("\n\n")
+		sb.WriteString(studyHelpStyle.Render("\ty: Study Ahead" + "\tn: Back to Decks" + "\tq: Quit"))
+		return sb.String()
+	}
+
 	// Handle edge case: no cards in the deck
 	if s.totalCards <= 0 {
 		return "No cards in this deck. Press 'b' to go back."
@@ -321,14 +647,47 @@ func (s *StudyScreen) View() string {
 	if s.state == FinishedStudying {
 		sb.WriteString(studyTitleStyle.Render("Study Session Complete!"))
 		sb.WriteString("\n\n")
-		sb.WriteString("You've completed all cards in this deck!")
+		sb.WriteString("You've completed all cards for this session!")
 		sb.WriteString("\n\n")
 		sb.WriteString("Press any key to view your statistics.")
+		if s.status != "" {
+			sb.WriteString("\n\n")
+			sb.WriteString(statLabelStyle.Render(s.status))
+		}
+		if len(s.rated) > 0 {
+			sb.WriteString("\n\n")
+			sb.WriteString(studyHelpStyle.Render("\tu: Undo Last Rating"))
+		}
 		return sb.String()
 	}
 
-	// Title and card count
+	// Wait for a card in learning, offering to study it now
+	if s.state == WaitingForCard {
+		sb.WriteString(studyTitleStyle.Render(fmt.Sprintf("Studying: %s", s.deck.Name)))
+		sb.WriteString("\n\n")
+		wait := (s.dueAt[s.cardIndex].Sub(s.now()) + time.Minute - 1).Truncate(time.Minute) // Rounded up
+		sb.WriteString(fmt.Sprintf("The next card in learning is due in %s.", formatInterval(wait)))
+		sb.WriteString("\n\n")
+		sb.WriteString(revealPromptStyle.Render("Press SPACE to study it now"))
+		sb.WriteString("\n\n")
+		s.writeStatus(&sb)
+		sb.WriteString(studyHelpStyle.Render("\tSPACE: Study Now" + "\tu: Undo" + "\tb: Back to Decks" + "\tq: Quit"))
+		return sb.String()
+	}
+
+	// Get the current card
+	currentCard, ok := s.currentCard()
+	if !ok {
+		return "No cards in this deck. Press 'b' to go back."
+	}
+
+	// Title with the deck of the card when it is not the studied deck, and card count
 	title := fmt.Sprintf("Studying: %s", s.deck.Name)
+	if s.store != nil && currentCard.DeckID != s.deckID {
+		if cardDeck, found := s.store.GetDeck(currentCard.DeckID); found {
+			title += " / " + cardDeck.Name
+		}
+	}
 	cardCount := fmt.Sprintf("Card %d/%d", s.cardIndex+1, s.totalCards)
 
 	sb.WriteString(studyTitleStyle.Render(title))
@@ -340,9 +699,6 @@ func (s *StudyScreen) View() string {
 	sb.WriteString(s.renderProgressBar())
 	sb.WriteString("\n\n")
 
-	// Get the current card
-	currentCard := s.cards[s.cardIndex]
-
 	// Question box with markdown rendering
 	renderedQuestion := s.markdownRenderer.Render(currentCard.Question)
 	sb.WriteString(questionStyle.Render(renderedQuestion))
@@ -354,30 +710,84 @@ func (s *StudyScreen) View() string {
 		sb.WriteString(answerStyle.Render(s.answerViewport.View()))
 		sb.WriteString("\n\n")
 
-		// Rating buttons
-		blackoutBtn := ratingBlackoutStyle.Render("Blackout (1)")
-		wrongBtn := ratingWrongStyle.Render("Wrong (2)")
-		hardBtn := ratingHardStyle.Render("Hard (3)")
-		goodBtn := ratingGoodStyle.Render("Good (4)")
-		easyBtn := ratingEasyStyle.Render("Easy (5)")
+		// Rating buttons, with the interval each rating would schedule
+		previews := s.previewIntervals(currentCard)
+		blackoutBtn := ratingBlackoutStyle.Render(ratingLabel("Blackout", 1, previews))
+		wrongBtn := ratingWrongStyle.Render(ratingLabel("Wrong", 2, previews))
+		hardBtn := ratingHardStyle.Render(ratingLabel("Hard", 3, previews))
+		goodBtn := ratingGoodStyle.Render(ratingLabel("Good", 4, previews))
+		easyBtn := ratingEasyStyle.Render(ratingLabel("Easy", 5, previews))
 
 		sb.WriteString(blackoutBtn + " " + wrongBtn + " " + hardBtn + " " + goodBtn + " " + easyBtn)
 		sb.WriteString("\n\n")
 
+		s.writeStatus(&sb)
+
 		// Help text for rating state
-		sb.WriteString(studyHelpStyle.Render("\t1-5: Rate Card" + "\tj/k: Scroll" + "\tb: Back to Decks" + "\tq: Quit"))
+		sb.WriteString(studyHelpStyle.Render("\t1-5: Rate Card" + "\tj/k: Scroll" + "\te: Edit" + "\t@: Suspend" + "\t-: Bury" + "\tu: Undo" + "\tb: Back to Decks" + "\tq: Quit"))
 	} else {
 		// Show the prompt to reveal the answer
 		sb.WriteString(revealPromptStyle.Render("Press SPACE to reveal answer"))
 		sb.WriteString("\n\n")
+		s.writeStatus(&sb)
 
 		// Help text for question state
-		sb.WriteString(studyHelpStyle.Render("\tSPACE: Show Answer" + "\t<: Skip" + "\tb: Back to Decks" + "\tq: Quit"))
+		sb.WriteString(studyHelpStyle.Render("\tSPACE: Show Answer" + "\t<: Skip" + "\te: Edit" + "\t@: Suspend" + "\t-: Bury" + "\tu: Undo" + "\tb: Back to Decks" + "\tq: Quit"))
 	}
 
 	return sb.String()
 }
 
+// writeStatus writes the outcome of the last action, if any
+func (s *StudyScreen) writeStatus(sb *strings.Builder) {
+	if s.status != "" {
+		sb.WriteString(statLabelStyle.Render(s.status))
+		sb.WriteString("\n\n")
+	}
+}
+
+// deckDescription names what is being studied, for use in sentences
+func (s *StudyScreen) deckDescription() string {
+	if s.filter != nil {
+		return fmt.Sprintf("the cards matching %q", s.filter.String())
+	}
+	if s.deckID == "" {
+		return "any deck"
+	}
+	return "this deck"
+}
+
+// previewIntervals returns the interval each rating would give the card
+// under the scheduler of its deck
+func (s *StudyScreen) previewIntervals(card model.Card) map[int]time.Duration {
+	if s.store == nil {
+		return nil
+	}
+	return s.store.SchedulerForDeck(card.DeckID).PreviewIntervals(card, s.store.Now())
+}
+
+// ratingLabel formats the label of a rating button, followed by the
+// interval the rating would schedule when it is known
+func ratingLabel(name string, rating int, previews map[int]time.Duration) string {
+	label := fmt.Sprintf("%s (%d)", name, rating)
+	if interval, ok := previews[rating]; ok {
+		label += " " + formatInterval(interval)
+	}
+	return label
+}
+
+// formatInterval formats a scheduling interval compactly, e.g. "10m", "3h" or "12d"
+func formatInterval(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Round(time.Minute).Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Round(time.Hour).Hours()))
+	default:
+		return fmt.Sprintf("%dd", int((d+12*time.Hour).Hours()/24))
+	}
+}
+
 // Helper function to get max of two integers
 func max(a, b int) int {
 	if a > b {
@@ -3,18 +3,33 @@
 package ui
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/DavidMiserak/GoCard/internal/clock"
 	"github.com/DavidMiserak/GoCard/internal/data"
 	"github.com/DavidMiserak/GoCard/internal/model"
+	"github.com/DavidMiserak/GoCard/internal/srs"
 )
 
-func TestStudyScreenView(t *testing.T) {
-	// Create a store with dummy data
+// newDueStore returns a store with dummy data a month from now, when all
+// of its cards are due
+func newDueStore() *data.Store {
 	store := data.NewStore()
+	store.SetClock(clock.Fixed(time.Now().AddDate(0, 1, 0)))
+	return store
+}
+
+func TestStudyScreenView(t *testing.T) {
+	// Create a store with dummy data, with every card due
+	store := newDueStore()
 
 	// Get the first deck ID for testing
 	decks := store.GetDecks()
@@ -54,8 +69,8 @@ func TestStudyScreenView(t *testing.T) {
 }
 
 func TestStudyScreenAnswerReveal(t *testing.T) {
-	// Create a store with dummy data
-	store := data.NewStore()
+	// Create a store with dummy data, with every card due
+	store := newDueStore()
 
 	// Get the first deck ID for testing
 	decks := store.GetDecks()
@@ -110,8 +125,8 @@ func TestStudyScreenAnswerReveal(t *testing.T) {
 }
 
 func TestStudyScreenNavigation(t *testing.T) {
-	// Create a store with dummy data
-	store := data.NewStore()
+	// Create a store with dummy data, with every card due
+	store := newDueStore()
 
 	// Get the first deck ID for testing
 	decks := store.GetDecks()
@@ -157,8 +172,8 @@ func TestStudyScreenNavigation(t *testing.T) {
 }
 
 func TestStudyScreenRating(t *testing.T) {
-	// Create a store with dummy data
-	store := data.NewStore()
+	// Create a store with dummy data, with every card due
+	store := newDueStore()
 
 	// Get the first deck ID for testing
 	decks := store.GetDecks()
@@ -246,8 +261,8 @@ func TestStudyScreenEmptyDeck(t *testing.T) {
 
 // New test for the finish studying functionality
 func TestStudyScreenFinishStudying(t *testing.T) {
-	// Create a store with dummy data
-	store := data.NewStore()
+	// Create a store with dummy data, with every card due
+	store := newDueStore()
 
 	// Get the first deck ID for testing
 	decks := store.GetDecks()
@@ -350,3 +365,319 @@ func TestStudyScreenAllCardsStudied(t *testing.T) {
 		t.Errorf("Expected state to be FinishedStudying when all cards are studied, got %v", study.state)
 	}
 }
+
+func TestStudyScreenLearningSteps(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	store := &data.Store{
+		Config: data.DefaultConfig(),
+		Decks: []model.Deck{
+			{ID: "deck", Name: "Deck", Cards: []model.Card{
+				{ID: "card", DeckID: "deck", Question: "Q", Answer: "A", NextReview: now, Ease: 2.5},
+			}},
+		},
+	}
+	store.SetClock(clock.Fixed(now))
+	store.SetScheduler(srs.SM2Scheduler{Steps: srs.Steps{Learning: srs.DefaultLearningSteps}})
+
+	study := NewStudyScreen(store, "deck")
+	rate := func(rating rune) tea.Cmd {
+		study.Update(tea.KeyMsg{Type: tea.KeySpace})
+		_, cmd := study.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{rating}})
+		return cmd
+	}
+
+	// A failed card comes back in the same session once its step is over
+	if cmd := rate('1'); cmd == nil {
+		t.Error("Expected a command to wait for the card in learning")
+	}
+	if study.state != WaitingForCard || study.totalCards != 2 {
+		t.Fatalf("Expected to wait for the requeued card, got state %v with %d cards", study.state, study.totalCards)
+	}
+	if view := study.View(); !strings.Contains(view, "due in 1m") {
+		t.Errorf("Expected view to show when the card is due, got:\n%s", view)
+	}
+
+	store.SetClock(clock.Fixed(now.Add(time.Minute)))
+	study.Update(learningDueMsg{})
+	if study.state != ShowingQuestion || study.cardIndex != 1 {
+		t.Fatalf("Expected the requeued card once its step is over, got state %v at card %d", study.state, study.cardIndex)
+	}
+
+	// Space studies a waiting card before its step is over
+	rate('4')
+	if study.state != WaitingForCard {
+		t.Fatalf("Expected to wait for the second step, got state %v", study.state)
+	}
+	study.Update(tea.KeyMsg{Type: tea.KeySpace})
+	if study.state != ShowingQuestion || study.cardIndex != 2 {
+		t.Fatalf("Expected to study the card early, got state %v at card %d", study.state, study.cardIndex)
+	}
+
+	// Graduating the card ends the session
+	rate('4')
+	if study.state != FinishedStudying {
+		t.Errorf("Expected the session to finish once the card graduates, got state %v", study.state)
+	}
+	if card, _ := store.GetCard("card"); card.State != model.StateReview {
+		t.Errorf("Expected the card to be in review, got %v", card.State)
+	}
+}
+
+func TestStudyScreenSuspendAndBury(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	store := &data.Store{
+		Config: data.DefaultConfig(),
+		Decks: []model.Deck{
+			{ID: "deck", Name: "Deck", Cards: []model.Card{
+				{ID: "a", DeckID: "deck", Question: "QA", Answer: "A", NextReview: now, Ease: 2.5, State: model.StateReview},
+				{ID: "b", DeckID: "deck", Question: "QB", Answer: "B", NextReview: now, Ease: 2.5, State: model.StateReview},
+			}},
+		},
+	}
+	store.SetClock(clock.Fixed(now))
+
+	study := NewStudyScreen(store, "deck")
+	if study.totalCards != 2 {
+		t.Fatalf("Expected 2 cards to study, got %d", study.totalCards)
+	}
+
+	// Suspending moves on to the next card and says so
+	study.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'@'}})
+	if study.state != ShowingQuestion || study.cardIndex != 1 {
+		t.Fatalf("Expected the next card after suspending, got state %v at card %d", study.state, study.cardIndex)
+	}
+	if view := study.View(); !strings.Contains(view, "suspended") {
+		t.Errorf("Expected view to say the card was suspended, got:\n%s", view)
+	}
+
+	// Burying the last card ends the session
+	study.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'-'}})
+	if study.state != FinishedStudying {
+		t.Errorf("Expected the session to finish, got state %v", study.state)
+	}
+
+	if card, _ := store.GetCard("a"); !card.Suspended {
+		t.Errorf("Expected card a to be suspended")
+	}
+	if card, _ := store.GetCard("b"); !store.IsBuried(card) {
+		t.Errorf("Expected card b to be buried")
+	}
+	if due := store.GetDueCardsForDeck("deck"); len(due) != 0 {
+		t.Errorf("Expected no cards due, got %d", len(due))
+	}
+}
+
+func TestStudyScreenUndoRating(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	original := model.Card{ID: "card", DeckID: "deck", Question: "Q", Answer: "A", NextReview: now,
+		LastReviewed: now.AddDate(0, 0, -20), Interval: 20, Ease: 2.5, State: model.StateReview}
+	store := &data.Store{
+		Config: data.DefaultConfig(),
+		Decks:  []model.Deck{{ID: "deck", Name: "Deck", Cards: []model.Card{original}}},
+	}
+	store.SetClock(clock.Fixed(now))
+	store.SetScheduler(srs.SM2Scheduler{Steps: srs.Steps{Relearning: []time.Duration{10 * time.Minute}}})
+
+	// Blackout on a review card sends it to relearning in this session
+	study := NewStudyScreen(store, "deck")
+	study.Update(tea.KeyMsg{Type: tea.KeySpace})
+	study.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	if study.state != WaitingForCard || study.totalCards != 2 {
+		t.Fatalf("Expected to wait for the relearning card, got state %v with %d cards", study.state, study.totalCards)
+	}
+
+	// Undo goes back to the card as it was, with nothing requeued
+	study.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	if study.state != ShowingQuestion || study.cardIndex != 0 || study.totalCards != 1 || len(study.studiedCards) != 0 {
+		t.Fatalf("Expected the first card shown again alone, got state %v at card %d of %d",
+			study.state, study.cardIndex, study.totalCards)
+	}
+	if card, _ := store.GetCard("card"); !reflect.DeepEqual(card, original) {
+		t.Errorf("Expected the card to be restored, got %+v", card)
+	}
+	if history := store.GetReviewHistory(); len(history) != 0 {
+		t.Errorf("Expected the review to be removed from the history, got %d reviews", len(history))
+	}
+
+	// A rating that finishes the session can be undone too
+	study.Update(tea.KeyMsg{Type: tea.KeySpace})
+	study.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'4'}})
+	if study.state != FinishedStudying {
+		t.Fatalf("Expected the session to finish, got state %v", study.state)
+	}
+	updatedModel, _ := study.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	if updatedModel != study || study.state != ShowingQuestion {
+		t.Errorf("Expected to study the card again after undo, got %T in state %v", updatedModel, study.state)
+	}
+
+	// With nothing left to undo the key does nothing
+	study.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	if study.state != ShowingQuestion || len(store.GetReviewHistory()) != 0 {
+		t.Errorf("Expected nothing to change, got state %v", study.state)
+	}
+}
+
+func TestStudyScreenNothingDue(t *testing.T) {
+	// None of the dummy cards are due today
+	store := data.NewStore()
+	deckID := store.GetDecks()[0].ID
+
+	study := NewStudyScreen(store, deckID)
+	if study.state != NothingDue {
+		t.Fatalf("Expected state to be NothingDue, got %v", study.state)
+	}
+	if view := study.View(); !strings.Contains(view, "Study ahead?") {
+		t.Errorf("Expected view to offer studying ahead, got:\n%s", view)
+	}
+
+	// Declining goes back to the decks
+	updatedModel, _ := study.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if _, ok := updatedModel.(*BrowseScreen); !ok {
+		t.Errorf("Expected *BrowseScreen after declining, got %T", updatedModel)
+	}
+
+	// Accepting studies the cards that are not due yet
+	study = NewStudyScreen(store, deckID)
+	updatedModel, _ = study.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	updatedStudy, ok := updatedModel.(*StudyScreen)
+	if !ok {
+		t.Fatalf("Expected *StudyScreen, got %T", updatedModel)
+	}
+	if updatedStudy.state != ShowingQuestion || updatedStudy.totalCards != len(store.GetDecks()[0].Cards) {
+		t.Errorf("Expected to study all %d cards ahead, got state %v with %d cards",
+			len(store.GetDecks()[0].Cards), updatedStudy.state, updatedStudy.totalCards)
+	}
+}
+
+func TestStudyScreenNothingToStudyAhead(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	store := &data.Store{
+		Config: data.DefaultConfig(),
+		Decks: []model.Deck{
+			{ID: "deck", Name: "Deck", Cards: []model.Card{
+				{ID: "a", DeckID: "deck", Question: "QA", Answer: "A", NextReview: now, Ease: 2.5, Suspended: true},
+				{ID: "b", DeckID: "deck", Question: "QB", Answer: "B", NextReview: now, Ease: 2.5, Suspended: true},
+			}},
+		},
+	}
+	store.SetClock(clock.Fixed(now))
+
+	// Suspended cards are not offered to study ahead, in a deck or all decks
+	for _, study := range []*StudyScreen{NewStudyScreen(store, "deck"), NewAllDecksStudyScreen(store)} {
+		if view := study.View(); strings.Contains(view, "Study ahead?") || !strings.Contains(view, "Nothing to study ahead") {
+			t.Errorf("Expected nothing offered to study ahead, got:\n%s", view)
+		}
+		updatedModel, _ := study.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+		if _, ok := updatedModel.(*BrowseScreen); !ok {
+			t.Errorf("Expected *BrowseScreen after y, got %T", updatedModel)
+		}
+	}
+
+	// A session without cards ignores the keys that act on the current card
+	study := newStudyScreen(store, "deck", model.Deck{Name: "Deck"}, nil)
+	for _, r := range []rune{' ', '@', '-', 'e', '4'} {
+		study.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	study.Update(tea.KeyMsg{Type: tea.KeySpace})
+	if view := study.View(); !strings.Contains(view, "No cards in this deck") {
+		t.Errorf("Expected the empty session, got:\n%s", view)
+	}
+}
+
+func TestAllDecksStudyScreen(t *testing.T) {
+	store := newDueStore()
+
+	// The Study menu entry studies every deck at once
+	updatedModel, _ := NewMainMenu(store).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	study, ok := updatedModel.(*StudyScreen)
+	if !ok {
+		t.Fatalf("Expected *StudyScreen after selecting Study, got %T", updatedModel)
+	}
+
+	totalCards := 0
+	for _, deck := range store.GetDecks() {
+		totalCards += len(deck.Cards)
+	}
+	if study.deckID != "" || study.totalCards != totalCards {
+		t.Errorf("Expected all %d cards of every deck, got %d for deck %q", totalCards, study.totalCards, study.deckID)
+	}
+
+	// The header names the deck of the current card
+	cardDeck, _ := store.GetDeck(study.cards[0].DeckID)
+	if view := study.View(); !strings.Contains(view, "Studying: All Decks / "+cardDeck.Name) {
+		t.Errorf("Expected header to name deck %q, got:\n%s", cardDeck.Name, view)
+	}
+}
+
+func TestStudyScreenEditCard(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+
+	tempDir := t.TempDir()
+	deckDir := filepath.Join(tempDir, "go")
+	if err := os.Mkdir(deckDir, 0755); err != nil {
+		t.Fatalf("Failed to create deck dir: %v", err)
+	}
+	path := filepath.Join(deckDir, "a.md")
+	writeCard := func(question string) {
+		content := "---\ntags: [go]\n---\n# Question\n" + question + "\n# Answer\nA\n"
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write card: %v", err)
+		}
+	}
+	writeCard("What is a gorutine?")
+
+	store, err := data.NewStoreFromDirWithClock(tempDir, clock.Fixed(now))
+	if err != nil {
+		t.Fatalf("NewStoreFromDirWithClock error: %v", err)
+	}
+	before, _ := store.GetCard(path)
+
+	study := NewStudyScreen(store, deckDir)
+
+	// e opens the card in the editor
+	if _, cmd := study.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}}); cmd == nil {
+		t.Fatal("Expected a command opening the editor")
+	}
+
+	// The fixed card is shown once the editor exits, with its schedule kept
+	writeCard("What is a goroutine?")
+	study.Update(cardEditedMsg{path: path})
+	if view := study.View(); !strings.Contains(view, "goroutine?") || !strings.Contains(view, "Card reloaded") {
+		t.Errorf("Expected the fixed question, got:\n%s", view)
+	}
+	if after, _ := store.GetCard(path); !after.NextReview.Equal(before.NextReview) || after.State != before.State {
+		t.Errorf("Expected the schedule to be kept, got %+v", after)
+	}
+
+	// An editor that fails leaves the card as it was
+	study.Update(cardEditedMsg{path: path, err: errors.New("exit status 1")})
+	if view := study.View(); !strings.Contains(view, "Editor failed: exit status 1") {
+		t.Errorf("Expected the editor failure, got:\n%s", view)
+	}
+}
+
+func TestStudyScreenSaveError(t *testing.T) {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "a.md")
+	if err := os.WriteFile(path, []byte("---\ntags: [go]\n---\n# Question\nQ\n# Answer\nA\n"), 0644); err != nil {
+		t.Fatalf("Failed to write card: %v", err)
+	}
+
+	store, err := data.NewStoreFromDirWithClock(tempDir, clock.Fixed(now))
+	if err != nil {
+		t.Fatalf("NewStoreFromDirWithClock error: %v", err)
+	}
+	study := NewStudyScreen(store, tempDir)
+
+	// The file loses its front matter before the card is rated
+	if err := os.WriteFile(path, []byte("# Question\nQ\n"), 0644); err != nil {
+		t.Fatalf("Failed to break card: %v", err)
+	}
+	study.Update(tea.KeyMsg{Type: tea.KeySpace})
+	study.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'4'}})
+	if view := study.View(); !strings.Contains(view, "Error: error saving card") {
+		t.Errorf("Expected the save error in the view, got:\n%s", view)
+	}
+}
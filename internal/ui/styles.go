@@ -168,3 +168,13 @@ var (
 var (
 	viewportStyle = lipgloss.NewStyle().Padding(1, 2)
 )
+
+// Load Problems Styles
+var (
+	warningStyle = lipgloss.NewStyle().
+			Foreground(ratingHardColor)
+
+	problemReasonStyle = lipgloss.NewStyle().
+				Foreground(colorLightGray).
+				PaddingLeft(4)
+)
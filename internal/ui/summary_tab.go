@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/DavidMiserak/GoCard/internal/data"
+	"github.com/DavidMiserak/GoCard/internal/model"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -48,7 +49,7 @@ func renderSummaryStats(store *data.Store) string {
 	sb.WriteString("\n\n")
 
 	// Render bar chart for cards studied per day
-	chart := renderHorizontalBarChart(cardsStudiedPerDay, 30)
+	chart := renderHorizontalBarChart(cardsStudiedPerDay, 30, store.Today())
 	sb.WriteString(chart)
 
 	return sb.String()
@@ -63,37 +64,24 @@ func getTotalCards(store *data.Store) int {
 	return count
 }
 
-// getCardsStudiedToday returns the number of cards studied today
+// getCardsStudiedToday returns the number of reviews made today
 func getCardsStudiedToday(store *data.Store) int {
-	count := 0
-	today := time.Now().Truncate(24 * time.Hour) // Start of today
-
-	for _, deck := range store.GetDecks() {
-		for _, card := range deck.Cards {
-			if card.LastReviewed.After(today) || card.LastReviewed.Equal(today) {
-				count++
-			}
-		}
-	}
-	return count
+	counts := reviewCountsByDay(store, store.GetReviewHistory(), 1)
+	return counts[0]
 }
 
-// calculateRetentionRate calculates retention rate based on card ratings
-// Ratings 4-5 are considered "retained"
+// calculateRetentionRate calculates retention rate based on the reviews
+// of today and the 30 days before. Ratings 4-5 are considered "retained"
 func calculateRetentionRate(store *data.Store) int {
 	var totalReviewed, retained int
 
 	// Get reviews from the last 30 days
-	thirtyDaysAgo := time.Now().AddDate(0, 0, -30)
+	thirtyDaysAgo := store.Today().AddDate(0, 0, -30)
 
-	for _, deck := range store.GetDecks() {
-		for _, card := range deck.Cards {
-			if card.LastReviewed.After(thirtyDaysAgo) {
-				totalReviewed++
-				if card.Rating >= 4 {
-					retained++
-				}
-			}
+	for _, event := range store.GetHistorySince(thirtyDaysAgo) {
+		totalReviewed++
+		if event.Rating >= 4 {
+			retained++
 		}
 	}
 
@@ -104,38 +92,40 @@ func calculateRetentionRate(store *data.Store) int {
 	return int((float64(retained) / float64(totalReviewed)) * 100)
 }
 
-// getCardsStudiedPerDay returns the number of cards studied per day for the last 6 days
+// getCardsStudiedPerDay returns the number of reviews made per day for the last 6 days
 func getCardsStudiedPerDay(store *data.Store) map[string]int {
-	// Initialize the last 6 days (including today)
+	today := store.Today()
+	counts := reviewCountsByDay(store, store.GetReviewHistory(), 6)
+
+	// Key each count by its date, oldest first
 	result := make(map[string]int)
-	for i := 5; i >= 0; i-- {
-		date := time.Now().AddDate(0, 0, -i)
-		dateStr := date.Format("Jan 2")
-		result[dateStr] = 0
+	for i, count := range counts {
+		date := today.AddDate(0, 0, i-len(counts)+1)
+		result[date.Format("Jan 2")] = count
 	}
 
-	// Count cards studied on each day
-	for _, deck := range store.GetDecks() {
-		for _, card := range deck.Cards {
-			// Skip cards that haven't been reviewed
-			if card.LastReviewed.IsZero() {
-				continue
-			}
-
-			// Check if the review was within the last 6 days
-			dayDiff := int(time.Since(card.LastReviewed).Hours() / 24)
-			if dayDiff <= 5 {
-				dateStr := card.LastReviewed.Format("Jan 2")
-				result[dateStr]++
-			}
+	return result
+}
+
+// reviewCountsByDay counts the reviews made on each of the last n days,
+// including today. The counts are ordered from oldest to newest.
+func reviewCountsByDay(store *data.Store, events []model.ReviewEvent, days int) []int {
+	counts := make([]int, days)
+
+	for _, event := range events {
+		// Number of days between the review and today
+		dayDiff := -store.DaysFromToday(event.Timestamp)
+		if dayDiff >= 0 && dayDiff < days {
+			counts[days-1-dayDiff]++
 		}
 	}
 
-	return result
+	return counts
 }
 
-// renderHorizontalBarChart creates a text-based horizontal bar chart for cards studied per day
-func renderHorizontalBarChart(data map[string]int, maxBarWidth int) string {
+// renderHorizontalBarChart creates a text-based horizontal bar chart for cards
+// studied per day, covering the 6 days up to today
+func renderHorizontalBarChart(data map[string]int, maxBarWidth int, today time.Time) string {
 	var sb strings.Builder
 
 	// Find the maximum value for scaling
@@ -154,7 +144,7 @@ func renderHorizontalBarChart(data map[string]int, maxBarWidth int) string {
 	// Sort dates from oldest to newest (last 6 days)
 	dates := make([]string, 0, 6)
 	for i := 5; i >= 0; i-- {
-		date := time.Now().AddDate(0, 0, -i)
+		date := today.AddDate(0, 0, -i)
 		dates = append(dates, date.Format("Jan 2"))
 	}
 
@@ -0,0 +1,155 @@
+// File: internal/ui/tag_filter_screen.go
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/DavidMiserak/GoCard/internal/data"
+)
+
+const (
+	// Number of known tags listed below the tag filter
+	tagsShown = 30
+)
+
+// Key mapping for the tag filter screen. Letters are typed into the
+// filter, so only special keys are bound.
+type tagFilterKeyMap struct {
+	Study key.Binding
+	Back  key.Binding
+	Quit  key.Binding
+}
+
+var tagFilterKeys = tagFilterKeyMap{
+	Study: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "study"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "back"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("ctrl+c"),
+		key.WithHelp("ctrl+c", "quit"),
+	),
+}
+
+// TagFilterScreen asks for a tag filter expression and starts a study
+// session with the matching cards of every deck
+type TagFilterScreen struct {
+	store  *data.Store
+	input  textinput.Model
+	err    string // Why the last filter could not be studied
+	width  int
+	height int
+}
+
+// NewTagFilterScreen creates a new tag filter screen
+func NewTagFilterScreen(store *data.Store) *TagFilterScreen {
+	input := textinput.New()
+	input.Placeholder = "go AND concurrency AND NOT basics"
+	input.CharLimit = 200
+	input.Width = 60
+	input.Focus()
+
+	return &TagFilterScreen{
+		store: store,
+		input: input,
+	}
+}
+
+// Init starts the cursor blinking
+func (t TagFilterScreen) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update handles user input and updates the model
+func (t TagFilterScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, tagFilterKeys.Quit):
+			return t, tea.Quit
+
+		case key.Matches(msg, tagFilterKeys.Back):
+			// Return to main menu
+			return NewMainMenu(t.store), nil
+
+		case key.Matches(msg, tagFilterKeys.Study):
+			filter, err := data.ParseTagFilter(t.input.Value())
+			switch {
+			case err != nil:
+				t.err = err.Error()
+			case len(t.store.TaggedCards(filter)) == 0:
+				t.err = "No cards match this filter"
+			default:
+				return NewTagStudyScreen(t.store, filter), nil
+			}
+			return t, nil
+		}
+
+	case tea.WindowSizeMsg:
+		t.width = 120 // Default width
+		t.height = msg.Height
+	}
+
+	// Everything else edits the filter
+	var cmd tea.Cmd
+	t.input, cmd = t.input.Update(msg)
+	if _, ok := msg.(tea.KeyMsg); ok {
+		t.err = ""
+	}
+	return t, cmd
+}
+
+// View renders the tag filter screen
+func (t TagFilterScreen) View() string {
+	s := headerStyle.Render("Study by Tag")
+	s += "\n\n"
+
+	s += t.input.View()
+	s += "\n\n"
+
+	// How many cards the filter matches, as it is typed
+	switch {
+	case t.err != "":
+		s += warningStyle.Render(t.err)
+	case strings.TrimSpace(t.input.Value()) == "":
+		s += statLabelStyle.Render("Combine tags with AND, OR, NOT and parentheses")
+	default:
+		if filter, err := data.ParseTagFilter(t.input.Value()); err == nil {
+			matching := len(t.store.TaggedCards(filter))
+			due := len(t.store.TagStudyQueue(filter))
+			s += statLabelStyle.Render(fmt.Sprintf("%d cards match, %d to study today", matching, due))
+		}
+	}
+	s += "\n\n"
+
+	// The tags there are to filter by
+	tags := t.store.Tags()
+	if len(tags) > 0 {
+		for i, tag := range tags {
+			if strings.ContainsAny(tag, " ()\"") {
+				tags[i] = fmt.Sprintf("%q", tag)
+			}
+		}
+		if len(tags) > tagsShown {
+			tags = append(tags[:tagsShown], fmt.Sprintf("and %d more", len(tags)-tagsShown))
+		}
+		s += normalRowStyle.Render("Tags: " + strings.Join(tags, ", "))
+		s += "\n\n"
+	}
+
+	// Help text
+	help := "\tEnter: Study" + "\tEsc: Back" + "\tCtrl+C: Quit"
+	s += browseHelpStyle.Render(help)
+
+	return s
+}
@@ -0,0 +1,112 @@
+// File: internal/ui/tag_filter_screen_test.go
+
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/DavidMiserak/GoCard/internal/clock"
+	"github.com/DavidMiserak/GoCard/internal/data"
+	"github.com/DavidMiserak/GoCard/internal/model"
+)
+
+// newTaggedStore returns a store with tagged cards in two decks, all due
+func newTaggedStore() *data.Store {
+	now := time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC)
+	due := func(id, deckID string, tags ...string) model.Card {
+		return model.Card{ID: id, DeckID: deckID, Question: id + "?", Answer: id, Tags: tags, LastReviewed: now.AddDate(0, 0, -3), NextReview: now.Add(-time.Hour)}
+	}
+
+	store := &data.Store{
+		Config: data.DefaultConfig(),
+		Decks: []model.Deck{
+			{ID: "go", Name: "Go", Cards: []model.Card{
+				due("goroutines", "go", "go", "concurrency"),
+				due("hello", "go", "go", "basics"),
+			}},
+			{ID: "rust", Name: "Rust", Cards: []model.Card{due("threads", "rust", "rust", "concurrency")}},
+		},
+	}
+	store.SetClock(clock.Fixed(now))
+	return store
+}
+
+// typeText sends text to a model one key at a time
+func typeText(m tea.Model, text string) tea.Model {
+	for _, r := range text {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return m
+}
+
+func TestTagFilterScreen(t *testing.T) {
+	store := newTaggedStore()
+
+	// Study by Tag is the second entry of the main menu
+	var m tea.Model = NewMainMenu(store)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if _, ok := m.(*TagFilterScreen); !ok {
+		t.Fatalf("Expected *TagFilterScreen after selecting Study by Tag, got %T", m)
+	}
+
+	// The known tags are listed
+	if view := m.View(); !strings.Contains(view, "basics, concurrency, go, rust") {
+		t.Errorf("Expected view to list the tags, got:\n%s", view)
+	}
+
+	// The number of matching cards updates as the filter is typed
+	m = typeText(m, "concurrency AND NOT basics")
+	if view := m.View(); !strings.Contains(view, "2 cards match, 2 to study today") {
+		t.Errorf("Expected view to count the matching cards, got:\n%s", view)
+	}
+
+	// Enter studies the matching cards across decks
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	study, ok := m.(*StudyScreen)
+	if !ok {
+		t.Fatalf("Expected *StudyScreen after entering a filter, got %T", m)
+	}
+	if study.totalCards != 2 {
+		t.Errorf("Expected 2 cards to study, got %d", study.totalCards)
+	}
+	for _, card := range study.cards {
+		if card.ID == "hello" {
+			t.Errorf("Expected card without the concurrency tag to be left out")
+		}
+	}
+	if view := study.View(); !strings.Contains(view, "Tags: concurrency AND NOT basics") {
+		t.Errorf("Expected header to name the tag filter, got:\n%s", view)
+	}
+}
+
+func TestTagFilterScreenErrors(t *testing.T) {
+	store := newTaggedStore()
+
+	// An invalid filter is reported and the screen stays
+	m := typeText(NewTagFilterScreen(store), "go AND")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if _, ok := m.(TagFilterScreen); !ok {
+		t.Fatalf("Expected to stay on the tag filter screen, got %T", m)
+	}
+	if view := m.View(); !strings.Contains(view, "tag filter ends where a tag is expected") {
+		t.Errorf("Expected view to show the parse error, got:\n%s", view)
+	}
+
+	// So is a filter matching no card
+	m = typeText(NewTagFilterScreen(store), "python")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if view := m.View(); !strings.Contains(view, "No cards match this filter") {
+		t.Errorf("Expected view to say nothing matches, got:\n%s", view)
+	}
+
+	// Esc goes back to the main menu
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if _, ok := m.(*MainMenu); !ok {
+		t.Errorf("Expected *MainMenu after esc, got %T", m)
+	}
+}